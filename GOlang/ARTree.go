@@ -0,0 +1,737 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+)
+
+// Виды узлов ARTree. Внутренние узлы адаптивно растут (Node4 -> Node16 ->
+// Node48 -> Node256) по мере увеличения числа детей и сжимаются обратно при
+// удалении, что экономит память на разреженных алфавитах по сравнению с
+// классическим trie с фиксированным массивом детей на 256 элементов.
+type artNodeKind byte
+
+const (
+	artKindNode4 artNodeKind = iota
+	artKindNode16
+	artKindNode48
+	artKindNode256
+)
+
+// artNode — узел Adaptive Radix Tree. isLeaf отличает лист (хранящий полный
+// ключ и значение, с ленивым расширением — одна запись на лист) от
+// внутреннего узла. prefix — сжатый общий участок пути (path compression):
+// последовательность байтов ключа, общая для всех потомков узла, хранится
+// один раз вместо того, чтобы создавать по промежуточному узлу на каждый байт.
+//
+// Ключи, где один является строгим префиксом другого (например, одновременная
+// вставка "foo" и "foobar"), поддерживаются через term: отдельное поле для
+// листа, чей ключ заканчивается ровно в этой точке дерева, а не через байт-
+// ребенка с меткой 0 — иначе он был бы неотличим от ребенка по литеральному
+// байту 0x00 в продолжающемся ключе.
+type artNode[V any] struct {
+	kind        artNodeKind
+	prefix      []byte
+	numChildren int
+
+	isLeaf  bool
+	leafKey []byte
+	leafVal V
+
+	// term хранит лист для ключа, который заканчивается ровно на этой глубине
+	// (другой ключ под этим узлом — его строгое продолжение). nil, если такого
+	// ключа нет. Не учитывается в numChildren.
+	term *artNode[V]
+
+	// Node4/Node16: параллельные слайсы отсортированы по keys для упорядоченного обхода.
+	keys     []byte
+	children []*artNode[V]
+
+	// Node48: index48[b] == 0 означает "нет ребенка для байта b", иначе
+	// children[index48[b]-1] — сам ребенок (1-based, чтобы 0 означал пустоту).
+	index48 []byte
+
+	// Node256: прямая адресация по байту без дополнительного уровня индирекции.
+	children256 []*artNode[V]
+}
+
+func newNode4[V any]() *artNode[V] {
+	return &artNode[V]{kind: artKindNode4, keys: make([]byte, 0, 4), children: make([]*artNode[V], 0, 4)}
+}
+
+func newNode16[V any]() *artNode[V] {
+	return &artNode[V]{kind: artKindNode16, keys: make([]byte, 0, 16), children: make([]*artNode[V], 0, 16)}
+}
+
+func newNode48[V any]() *artNode[V] {
+	return &artNode[V]{kind: artKindNode48, index48: make([]byte, 256), children: make([]*artNode[V], 0, 48)}
+}
+
+func newNode256[V any]() *artNode[V] {
+	return &artNode[V]{kind: artKindNode256, children256: make([]*artNode[V], 256)}
+}
+
+func makeLeaf[V any](key []byte, v V) *artNode[V] {
+	return &artNode[V]{isLeaf: true, leafKey: append([]byte{}, key...), leafVal: v}
+}
+
+// ARTree — ассоциативный контейнер с упорядоченными строковыми (байтовыми)
+// ключами на основе Adaptive Radix Tree. В отличие от HashTable, дает
+// упорядоченный обход, Minimum/Maximum и эффективный поиск по префиксу.
+// Zero-value ARTree — валидное пустое дерево; NewARTree() возвращает такое же.
+type ARTree[V any] struct {
+	root *artNode[V]
+	size int
+}
+
+// NewARTree создает пустое дерево.
+func NewARTree[V any]() *ARTree[V] {
+	return &ARTree[V]{}
+}
+
+// matchPrefix возвращает число байтов, на которое prefix совпадает с
+// key[depth:], не выходя за границы ни одного из них.
+func matchPrefix(prefix, key []byte, depth int) int {
+	i := 0
+	for i < len(prefix) && depth+i < len(key) && prefix[i] == key[depth+i] {
+		i++
+	}
+	return i
+}
+
+func getChild[V any](n *artNode[V], c byte) *artNode[V] {
+	switch n.kind {
+	case artKindNode4, artKindNode16:
+		for i, k := range n.keys {
+			if k == c {
+				return n.children[i]
+			}
+		}
+	case artKindNode48:
+		idx := n.index48[c]
+		if idx != 0 {
+			return n.children[idx-1]
+		}
+	case artKindNode256:
+		return n.children256[c]
+	}
+	return nil
+}
+
+func getChildRef[V any](n *artNode[V], c byte) **artNode[V] {
+	switch n.kind {
+	case artKindNode4, artKindNode16:
+		for i, k := range n.keys {
+			if k == c {
+				return &n.children[i]
+			}
+		}
+	case artKindNode48:
+		idx := n.index48[c]
+		if idx != 0 {
+			return &n.children[idx-1]
+		}
+	case artKindNode256:
+		return &n.children256[c]
+	}
+	return nil
+}
+
+// insertSorted вставляет ребенка в Node4/Node16, сохраняя keys отсортированными
+// по возрастанию байта — это дает Minimum/Maximum/PrefixScan обход в порядке
+// без дополнительной сортировки.
+func insertSorted[V any](n *artNode[V], c byte, child *artNode[V]) {
+	pos := 0
+	for pos < len(n.keys) && n.keys[pos] < c {
+		pos++
+	}
+	n.keys = append(n.keys, 0)
+	copy(n.keys[pos+1:], n.keys[pos:])
+	n.keys[pos] = c
+
+	n.children = append(n.children, nil)
+	copy(n.children[pos+1:], n.children[pos:])
+	n.children[pos] = child
+	n.numChildren++
+}
+
+func growToNode16[V any](n *artNode[V]) *artNode[V] {
+	newNode := newNode16[V]()
+	newNode.prefix = n.prefix
+	newNode.term = n.term
+	newNode.keys = append(newNode.keys, n.keys...)
+	newNode.children = append(newNode.children, n.children...)
+	newNode.numChildren = n.numChildren
+	return newNode
+}
+
+func growToNode48[V any](n *artNode[V]) *artNode[V] {
+	newNode := newNode48[V]()
+	newNode.prefix = n.prefix
+	newNode.term = n.term
+	for i, k := range n.keys {
+		newNode.children = append(newNode.children, n.children[i])
+		newNode.index48[k] = byte(len(newNode.children))
+	}
+	newNode.numChildren = n.numChildren
+	return newNode
+}
+
+func growToNode256[V any](n *artNode[V]) *artNode[V] {
+	newNode := newNode256[V]()
+	newNode.prefix = n.prefix
+	newNode.term = n.term
+	for b := 0; b < 256; b++ {
+		idx := n.index48[byte(b)]
+		if idx != 0 {
+			newNode.children256[byte(b)] = n.children[idx-1]
+		}
+	}
+	newNode.numChildren = n.numChildren
+	return newNode
+}
+
+func shrinkToNode48[V any](n *artNode[V]) *artNode[V] {
+	newNode := newNode48[V]()
+	newNode.prefix = n.prefix
+	newNode.term = n.term
+	for b := 0; b < 256; b++ {
+		if n.children256[byte(b)] != nil {
+			newNode.children = append(newNode.children, n.children256[byte(b)])
+			newNode.index48[byte(b)] = byte(len(newNode.children))
+		}
+	}
+	newNode.numChildren = n.numChildren
+	return newNode
+}
+
+func shrinkToNode16[V any](n *artNode[V]) *artNode[V] {
+	newNode := newNode16[V]()
+	newNode.prefix = n.prefix
+	newNode.term = n.term
+	for b := 0; b < 256; b++ {
+		idx := n.index48[byte(b)]
+		if idx != 0 {
+			newNode.keys = append(newNode.keys, byte(b))
+			newNode.children = append(newNode.children, n.children[idx-1])
+		}
+	}
+	newNode.numChildren = n.numChildren
+	return newNode
+}
+
+func shrinkToNode4[V any](n *artNode[V]) *artNode[V] {
+	newNode := newNode4[V]()
+	newNode.prefix = n.prefix
+	newNode.term = n.term
+	newNode.keys = append(newNode.keys, n.keys...)
+	newNode.children = append(newNode.children, n.children...)
+	newNode.numChildren = n.numChildren
+	return newNode
+}
+
+// addChild добавляет ребенка c -> child к n, при необходимости перерастив n в
+// узел большего вида (Node4 -> Node16 -> Node48 -> Node256). Возвращает узел,
+// который вызывающий обязан сохранить на место n (сам n может устареть).
+func addChild[V any](n *artNode[V], c byte, child *artNode[V]) *artNode[V] {
+	switch n.kind {
+	case artKindNode4:
+		if n.numChildren < 4 {
+			insertSorted(n, c, child)
+			return n
+		}
+		return addChild(growToNode16(n), c, child)
+	case artKindNode16:
+		if n.numChildren < 16 {
+			insertSorted(n, c, child)
+			return n
+		}
+		return addChild(growToNode48(n), c, child)
+	case artKindNode48:
+		if n.numChildren < 48 {
+			n.children = append(n.children, child)
+			n.index48[c] = byte(len(n.children))
+			n.numChildren++
+			return n
+		}
+		return addChild(growToNode256(n), c, child)
+	case artKindNode256:
+		if n.children256[c] == nil {
+			n.numChildren++
+		}
+		n.children256[c] = child
+		return n
+	}
+	return n
+}
+
+// mergeSingleChild заменяет Node4 с единственным оставшимся ребенком этим
+// ребенком напрямую, срастив сжатые префиксы, чтобы высота дерева не росла
+// из-за узлов с одним потомком.
+func mergeSingleChild[V any](n *artNode[V]) *artNode[V] {
+	childByte := n.keys[0]
+	child := n.children[0]
+	if !child.isLeaf {
+		merged := append([]byte{}, n.prefix...)
+		merged = append(merged, childByte)
+		merged = append(merged, child.prefix...)
+		child.prefix = merged
+	}
+	return child
+}
+
+// removeChild удаляет ребенка c из n, при необходимости сжимая n в узел
+// меньшего вида, и схлопывает Node4 с единственным ребенком через
+// mergeSingleChild. Возвращает узел, который нужно сохранить на место n.
+func removeChild[V any](n *artNode[V], c byte) *artNode[V] {
+	switch n.kind {
+	case artKindNode4, artKindNode16:
+		for i, k := range n.keys {
+			if k == c {
+				n.keys = append(n.keys[:i], n.keys[i+1:]...)
+				n.children = append(n.children[:i], n.children[i+1:]...)
+				n.numChildren--
+				break
+			}
+		}
+	case artKindNode48:
+		idx := n.index48[c]
+		if idx != 0 {
+			lastIdx := len(n.children)
+			removedPos := int(idx) - 1
+			lastChild := n.children[lastIdx-1]
+			n.children[removedPos] = lastChild
+			n.children = n.children[:lastIdx-1]
+			if removedPos != lastIdx-1 {
+				for b := 0; b < 256; b++ {
+					if n.index48[byte(b)] == byte(lastIdx) {
+						n.index48[byte(b)] = byte(removedPos + 1)
+						break
+					}
+				}
+			}
+			n.index48[c] = 0
+			n.numChildren--
+		}
+	case artKindNode256:
+		if n.children256[c] != nil {
+			n.children256[c] = nil
+			n.numChildren--
+		}
+	}
+
+	switch {
+	case n.kind == artKindNode256 && n.numChildren <= 48:
+		n = shrinkToNode48(n)
+	case n.kind == artKindNode48 && n.numChildren <= 16:
+		n = shrinkToNode16(n)
+	case n.kind == artKindNode16 && n.numChildren <= 4:
+		n = shrinkToNode4(n)
+	}
+
+	if n.kind == artKindNode4 && n.numChildren == 1 && n.term == nil {
+		return mergeSingleChild(n)
+	}
+	return n
+}
+
+// insertARTNode вставляет key/v, начиная с узла *ref на глубине depth.
+// Возвращает true, если это была новая вставка (а не обновление существующего ключа).
+func insertARTNode[V any](ref **artNode[V], key []byte, depth int, v V) bool {
+	n := *ref
+	if n == nil {
+		*ref = makeLeaf(key, v)
+		return true
+	}
+
+	if n.isLeaf {
+		if bytes.Equal(n.leafKey, key) {
+			n.leafVal = v
+			return false
+		}
+		i := matchPrefix(n.leafKey[depth:], key, depth)
+		newNode := newNode4[V]()
+		newNode.prefix = append([]byte{}, key[depth:depth+i]...)
+		addChildOrTerm(newNode, n.leafKey, depth+i, n)
+		addChildOrTerm(newNode, key, depth+i, makeLeaf(key, v))
+		*ref = newNode
+		return true
+	}
+
+	if len(n.prefix) > 0 {
+		matched := matchPrefix(n.prefix, key, depth)
+		if matched < len(n.prefix) {
+			newNode := newNode4[V]()
+			newNode.prefix = append([]byte{}, n.prefix[:matched]...)
+			oldChildByte := n.prefix[matched]
+			n.prefix = append([]byte{}, n.prefix[matched+1:]...)
+			addChild(newNode, oldChildByte, n)
+
+			addChildOrTerm(newNode, key, depth+matched, makeLeaf(key, v))
+
+			*ref = newNode
+			return true
+		}
+		depth += len(n.prefix)
+	}
+
+	// Ключ заканчивается ровно на этой глубине — значит, он строгий префикс
+	// всех ключей дальше по дереву. Храним его в n.term, а не как ребенка по
+	// байту 0, иначе он был бы неотличим от продолжения с литеральным 0x00.
+	if depth >= len(key) {
+		if n.term == nil {
+			n.term = makeLeaf(key, v)
+			return true
+		}
+		n.term.leafVal = v
+		return false
+	}
+
+	c := key[depth]
+	childRef := getChildRef(n, c)
+	if childRef == nil {
+		*ref = addChild(n, c, makeLeaf(key, v))
+		return true
+	}
+	return insertARTNode(childRef, key, depth+1, v)
+}
+
+// addChildOrTerm добавляет child к n по байту key[pos], либо, если pos уже
+// вышел за границы key (ключ заканчивается ровно здесь), сохраняет child как
+// n.term — отдельно от обычных byte-детей, чтобы не схлопывать "ключ
+// закончился" и "следующий байт ключа — 0x00" в одно и то же значение.
+func addChildOrTerm[V any](n *artNode[V], key []byte, pos int, child *artNode[V]) {
+	if pos >= len(key) {
+		n.term = child
+		return
+	}
+	addChild(n, key[pos], child)
+}
+
+// Insert вставляет или обновляет пару ключ-значение.
+func (t *ARTree[V]) Insert(key []byte, v V) {
+	if insertARTNode(&t.root, key, 0, v) {
+		t.size++
+	}
+}
+
+// Get получает значение по ключу.
+func (t *ARTree[V]) Get(key []byte) (V, bool) {
+	n := t.root
+	depth := 0
+	for n != nil {
+		if n.isLeaf {
+			if bytes.Equal(n.leafKey, key) {
+				return n.leafVal, true
+			}
+			var zero V
+			return zero, false
+		}
+		if len(n.prefix) > 0 {
+			matched := matchPrefix(n.prefix, key, depth)
+			if matched < len(n.prefix) {
+				var zero V
+				return zero, false
+			}
+			depth += len(n.prefix)
+		}
+		if depth >= len(key) {
+			if n.term != nil {
+				return n.term.leafVal, true
+			}
+			var zero V
+			return zero, false
+		}
+		n = getChild(n, key[depth])
+		depth++
+	}
+	var zero V
+	return zero, false
+}
+
+// deleteARTNode удаляет key из поддерева *ref. Возвращает true, если ключ был найден и удален.
+func deleteARTNode[V any](ref **artNode[V], key []byte, depth int) bool {
+	n := *ref
+	if n == nil {
+		return false
+	}
+	if n.isLeaf {
+		if bytes.Equal(n.leafKey, key) {
+			*ref = nil
+			return true
+		}
+		return false
+	}
+	if len(n.prefix) > 0 {
+		matched := matchPrefix(n.prefix, key, depth)
+		if matched < len(n.prefix) {
+			return false
+		}
+		depth += len(n.prefix)
+	}
+
+	if depth >= len(key) {
+		if n.term == nil || !bytes.Equal(n.term.leafKey, key) {
+			return false
+		}
+		n.term = nil
+		if n.kind == artKindNode4 && n.numChildren == 1 {
+			*ref = mergeSingleChild(n)
+		}
+		return true
+	}
+
+	c := key[depth]
+	childRef := getChildRef(n, c)
+	if childRef == nil {
+		return false
+	}
+	child := *childRef
+
+	if child.isLeaf {
+		if !bytes.Equal(child.leafKey, key) {
+			return false
+		}
+		*ref = removeChild(n, c)
+		return true
+	}
+	return deleteARTNode(childRef, key, depth+1)
+}
+
+// Delete удаляет элемент по ключу. Возвращает true, если ключ существовал.
+func (t *ARTree[V]) Delete(key []byte) bool {
+	if deleteARTNode(&t.root, key, 0) {
+		t.size--
+		return true
+	}
+	return false
+}
+
+func orderedChildren[V any](n *artNode[V]) []*artNode[V] {
+	switch n.kind {
+	case artKindNode4, artKindNode16:
+		return n.children
+	case artKindNode48:
+		result := make([]*artNode[V], 0, n.numChildren)
+		for b := 0; b < 256; b++ {
+			idx := n.index48[byte(b)]
+			if idx != 0 {
+				result = append(result, n.children[idx-1])
+			}
+		}
+		return result
+	case artKindNode256:
+		result := make([]*artNode[V], 0, n.numChildren)
+		for b := 0; b < 256; b++ {
+			if n.children256[byte(b)] != nil {
+				result = append(result, n.children256[byte(b)])
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func firstLeaf[V any](n *artNode[V]) *artNode[V] {
+	if n == nil {
+		return nil
+	}
+	if n.isLeaf {
+		return n
+	}
+	// term — это ключ, строго меньший (как префикс) любого ключа под byte-
+	// детьми этого узла, поэтому в лексикографическом порядке он идет первым.
+	if n.term != nil {
+		return n.term
+	}
+	children := orderedChildren(n)
+	if len(children) == 0 {
+		return nil
+	}
+	return firstLeaf(children[0])
+}
+
+func lastLeaf[V any](n *artNode[V]) *artNode[V] {
+	if n == nil {
+		return nil
+	}
+	if n.isLeaf {
+		return n
+	}
+	children := orderedChildren(n)
+	if len(children) > 0 {
+		return lastLeaf(children[len(children)-1])
+	}
+	return n.term
+}
+
+// Minimum возвращает ключ и значение с наименьшим ключом (в лексикографическом
+// порядке байтов), либо false, если дерево пусто.
+func (t *ARTree[V]) Minimum() ([]byte, V, bool) {
+	leaf := firstLeaf(t.root)
+	if leaf == nil {
+		var zero V
+		return nil, zero, false
+	}
+	return leaf.leafKey, leaf.leafVal, true
+}
+
+// Maximum возвращает ключ и значение с наибольшим ключом, либо false, если дерево пусто.
+func (t *ARTree[V]) Maximum() ([]byte, V, bool) {
+	leaf := lastLeaf(t.root)
+	if leaf == nil {
+		var zero V
+		return nil, zero, false
+	}
+	return leaf.leafKey, leaf.leafVal, true
+}
+
+func walkAllLeaves[V any](n *artNode[V], fn func([]byte, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.isLeaf {
+		return fn(n.leafKey, n.leafVal)
+	}
+	if n.term != nil {
+		if !fn(n.term.leafKey, n.term.leafVal) {
+			return false
+		}
+	}
+	for _, child := range orderedChildren(n) {
+		if !walkAllLeaves(child, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func walkPrefix[V any](n *artNode[V], prefix []byte, depth int, fn func([]byte, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.isLeaf {
+		if bytes.HasPrefix(n.leafKey, prefix) {
+			return fn(n.leafKey, n.leafVal)
+		}
+		return true
+	}
+
+	remain := len(prefix) - depth
+	if remain <= 0 {
+		return walkAllLeaves(n, fn)
+	}
+
+	if len(n.prefix) > 0 {
+		m := matchPrefix(n.prefix, prefix, depth)
+		if m < len(n.prefix) && m < remain {
+			return true
+		}
+		depth += m
+		if depth >= len(prefix) {
+			return walkAllLeaves(n, fn)
+		}
+	}
+
+	c := prefix[depth]
+	return walkPrefix(getChild(n, c), prefix, depth+1, fn)
+}
+
+// PrefixScan обходит все ключи, начинающиеся с prefix, в лексикографическом
+// порядке, вызывая fn(key, value) для каждого. Обход прекращается, как только
+// fn вернет false.
+func (t *ARTree[V]) PrefixScan(prefix []byte, fn func(k []byte, v V) bool) {
+	walkPrefix(t.root, prefix, 0, fn)
+}
+
+// Size возвращает текущее количество элементов.
+func (t *ARTree[V]) Size() int {
+	return t.size
+}
+
+// IsEmpty проверяет, пусто ли дерево.
+func (t *ARTree[V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear полностью очищает дерево.
+func (t *ARTree[V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// ARTNodeCounts — распределение внутренних узлов по видам и количество
+// листьев, используемое тестами для проверки адаптивного роста.
+type ARTNodeCounts struct {
+	Node4   int
+	Node16  int
+	Node48  int
+	Node256 int
+	Leaves  int
+}
+
+func countNodeKinds[V any](n *artNode[V], counts *ARTNodeCounts) {
+	if n == nil {
+		return
+	}
+	if n.isLeaf {
+		counts.Leaves++
+		return
+	}
+	switch n.kind {
+	case artKindNode4:
+		counts.Node4++
+	case artKindNode16:
+		counts.Node16++
+	case artKindNode48:
+		counts.Node48++
+	case artKindNode256:
+		counts.Node256++
+	}
+	if n.term != nil {
+		counts.Leaves++
+	}
+	for _, child := range orderedChildren(n) {
+		countNodeKinds(child, counts)
+	}
+}
+
+// CountNodeKinds возвращает распределение узлов дерева по видам — используется
+// в тестах, чтобы убедиться, что адаптивный рост/сжатие происходит как ожидается.
+func (t *ARTree[V]) CountNodeKinds() ARTNodeCounts {
+	var counts ARTNodeCounts
+	countNodeKinds(t.root, &counts)
+	return counts
+}
+
+// artEntry используется для gob-сериализации содержимого дерева в виде
+// плоского списка пар ключ-значение (внутренняя структура узлов не сохраняется).
+type artEntry[V any] struct {
+	Key   []byte
+	Value V
+}
+
+// Serialize сохраняет дерево в бинарном формате (gob) как отсортированный
+// список пар ключ-значение.
+func (t *ARTree[V]) Serialize(w io.Writer) error {
+	entries := make([]artEntry[V], 0, t.size)
+	t.PrefixScan(nil, func(k []byte, v V) bool {
+		entries = append(entries, artEntry[V]{Key: append([]byte{}, k...), Value: v})
+		return true
+	})
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+// Deserialize восстанавливает дерево из формата, записанного Serialize.
+func (t *ARTree[V]) Deserialize(r io.Reader) error {
+	var entries []artEntry[V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+	t.Clear()
+	for _, e := range entries {
+		t.Insert(e.Key, e.Value)
+	}
+	return nil
+}
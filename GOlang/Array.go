@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/gob"
 	"errors"
 	"fmt"
@@ -180,15 +181,20 @@ func (a *Array[T]) Print() {
 
 // Serialize выполняет сериализацию данных в поток writer.
 // Использует encoding/gob вместо raw bytes для безопасности типов.
+// Кодирование идет во временный буфер из bufferpool (а не напрямую в w),
+// чтобы повторные вызовы Serialize не аллоцировали новый буфер каждый раз.
 func (a *Array[T]) Serialize(w io.Writer) error {
-	encoder := gob.NewEncoder(w)
-	
 	// Сохраняем только активную часть данных
 	activeData := a.data[:a.size]
-	
-	if err := encoder.Encode(activeData); err != nil {
+
+	buf := bytes.NewBuffer(bufferPoolGet(len(activeData) * 16))
+	if err := gob.NewEncoder(buf).Encode(activeData); err != nil {
 		return fmt.Errorf("failed to encode data: %w", err)
 	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write encoded data: %w", err)
+	}
+	bufferPoolPut(buf.Bytes())
 	return nil
 }
 
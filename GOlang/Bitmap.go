@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"iter"
+	"math/bits"
+)
+
+// Bitmap реализует плотное множество неотрицательных целых чисел поверх
+// упакованного массива []uint64 (1 бит на элемент). Для разреженных или
+// больших ключей HashTable экономнее, но для плотных целочисленных множеств
+// Bitmap дает O(1) проверку принадлежности при минимальном расходе памяти.
+// Zero-value для Bitmap не является валидным для использования,
+// используйте NewBitmap().
+type Bitmap struct {
+	words []uint64
+}
+
+// NewBitmap создает битовую карту, способную сразу вместить initialBits бит
+// без дополнительного роста (минимум одно слово).
+func NewBitmap(initialBits uint) *Bitmap {
+	wordCount := initialBits/64 + 1
+	return &Bitmap{words: make([]uint64, wordCount)}
+}
+
+// ensureWord гарантирует, что слово с индексом wordIdx существует, удваивая
+// емкость при необходимости (аналог Array.resize).
+func (bm *Bitmap) ensureWord(wordIdx int) {
+	if wordIdx < len(bm.words) {
+		return
+	}
+	newLen := len(bm.words)
+	if newLen == 0 {
+		newLen = 1
+	}
+	for newLen <= wordIdx {
+		newLen *= 2
+	}
+	newWords := make([]uint64, newLen)
+	copy(newWords, bm.words)
+	bm.words = newWords
+}
+
+// Set устанавливает бит с индексом i, расширяя битовую карту при необходимости.
+func (bm *Bitmap) Set(i uint) {
+	wordIdx := int(i / 64)
+	bm.ensureWord(wordIdx)
+	bm.words[wordIdx] |= 1 << (i % 64)
+}
+
+// Clear сбрасывает бит с индексом i.
+func (bm *Bitmap) Clear(i uint) {
+	wordIdx := int(i / 64)
+	if wordIdx >= len(bm.words) {
+		return
+	}
+	bm.words[wordIdx] &^= 1 << (i % 64)
+}
+
+// Exists проверяет, установлен ли бит с индексом i.
+func (bm *Bitmap) Exists(i uint) bool {
+	wordIdx := int(i / 64)
+	if wordIdx >= len(bm.words) {
+		return false
+	}
+	return bm.words[wordIdx]&(1<<(i%64)) != 0
+}
+
+// Union возвращает новую битовую карту — объединение bm и other.
+func (bm *Bitmap) Union(other *Bitmap) *Bitmap {
+	n := len(bm.words)
+	if len(other.words) > n {
+		n = len(other.words)
+	}
+	result := &Bitmap{words: make([]uint64, n)}
+	for i := 0; i < n; i++ {
+		var a, b uint64
+		if i < len(bm.words) {
+			a = bm.words[i]
+		}
+		if i < len(other.words) {
+			b = other.words[i]
+		}
+		result.words[i] = a | b
+	}
+	return result
+}
+
+// Intersect возвращает новую битовую карту — пересечение bm и other.
+func (bm *Bitmap) Intersect(other *Bitmap) *Bitmap {
+	n := len(bm.words)
+	if len(other.words) < n {
+		n = len(other.words)
+	}
+	result := &Bitmap{words: make([]uint64, n)}
+	for i := 0; i < n; i++ {
+		result.words[i] = bm.words[i] & other.words[i]
+	}
+	return result
+}
+
+// Difference возвращает новую битовую карту — элементы bm, отсутствующие в other.
+func (bm *Bitmap) Difference(other *Bitmap) *Bitmap {
+	result := &Bitmap{words: make([]uint64, len(bm.words))}
+	for i := range bm.words {
+		var b uint64
+		if i < len(other.words) {
+			b = other.words[i]
+		}
+		result.words[i] = bm.words[i] &^ b
+	}
+	return result
+}
+
+// Inverse возвращает новую битовую карту с инвертированными битами в текущих
+// границах bm (len(bm.words)*64 бит); новых слов она не добавляет.
+func (bm *Bitmap) Inverse() *Bitmap {
+	result := &Bitmap{words: make([]uint64, len(bm.words))}
+	for i, w := range bm.words {
+		result.words[i] = ^w
+	}
+	return result
+}
+
+// PopCount возвращает количество установленных битов.
+func (bm *Bitmap) PopCount() int {
+	count := 0
+	for _, w := range bm.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// IsEmpty проверяет, что ни один бит не установлен.
+func (bm *Bitmap) IsEmpty() bool {
+	return bm.PopCount() == 0
+}
+
+// Clear полностью сбрасывает все биты (метод без аргументов переопределен как ClearAll,
+// так как Clear(i uint) уже занимает это имя для точечного сброса).
+func (bm *Bitmap) ClearAll() {
+	for i := range bm.words {
+		bm.words[i] = 0
+	}
+}
+
+// SetBits возвращает последовательность индексов установленных битов в
+// порядке возрастания, используя bits.TrailingZeros64 для пропуска нулевых
+// битов каждого ненулевого слова.
+func (bm *Bitmap) SetBits() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for wordIdx, w := range bm.words {
+			for w != 0 {
+				tz := bits.TrailingZeros64(w)
+				if !yield(uint(wordIdx)*64 + uint(tz)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Print выводит индексы установленных битов в консоль.
+func (bm *Bitmap) Print() {
+	fmt.Print("Bitmap {")
+	first := true
+	for i := range bm.SetBits() {
+		if !first {
+			fmt.Print(", ")
+		}
+		fmt.Print(i)
+		first = false
+	}
+	fmt.Println("}")
+}
+
+// Serialize сохраняет битовую карту в бинарном формате (используя gob).
+func (bm *Bitmap) Serialize(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(bm.words)
+}
+
+// Deserialize восстанавливает битовую карту из бинарного формата (gob).
+func (bm *Bitmap) Deserialize(r io.Reader) error {
+	return gob.NewDecoder(r).Decode(&bm.words)
+}
+
+// SerializeCompact сохраняет битовую карту в компактном бинарном формате:
+// количество слов как uint64 (big-endian), за которым следуют сами слова,
+// тоже big-endian. В отличие от Serialize (gob), не несет накладных расходов
+// на типовую информацию — пригодно для передачи по сети или на диск.
+func (bm *Bitmap) SerializeCompact(w io.Writer) error {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint64(header, uint64(len(bm.words)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 8)
+	for _, word := range bm.words {
+		binary.BigEndian.PutUint64(buf, word)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeserializeCompact восстанавливает битовую карту из формата SerializeCompact.
+func (bm *Bitmap) DeserializeCompact(r io.Reader) error {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+	wordCount := binary.BigEndian.Uint64(header)
+
+	words := make([]uint64, wordCount)
+	buf := make([]byte, 8)
+	for i := range words {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return err
+		}
+		words[i] = binary.BigEndian.Uint64(buf)
+	}
+	bm.words = words
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Размеры ярусов пула буферов. Get(size) отдает буфер из наименьшего яруса,
+// чья емкость >= size, чтобы не плодить промежуточные аллокации при росте
+// буфера через append.
+var bufferPoolTiers = [...]int{4 * 1024, 64 * 1024, 1024 * 1024, 4 * 1024 * 1024}
+
+var bufferPools = [len(bufferPoolTiers)]sync.Pool{}
+
+func init() {
+	for i := range bufferPoolTiers {
+		size := bufferPoolTiers[i]
+		bufferPools[i].New = func() any {
+			buf := make([]byte, 0, size)
+			return &buf
+		}
+	}
+}
+
+// bufferPoolHits и bufferPoolMisses считают попадания/промахи пула для
+// инструментирования (BufferPoolStats).
+var (
+	bufferPoolHits   atomic.Int64
+	bufferPoolMisses atomic.Int64
+)
+
+// BufferPoolStats — снимок статистики использования пула буферов.
+type BufferPoolStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// GetBufferPoolStats возвращает текущий снимок статистики попаданий/промахов
+// пула буферов, используемого Serialize*/Deserialize* методами контейнеров.
+func GetBufferPoolStats() BufferPoolStats {
+	return BufferPoolStats{
+		Hits:   bufferPoolHits.Load(),
+		Misses: bufferPoolMisses.Load(),
+	}
+}
+
+// bufferPoolGet возвращает буфер (длиной 0) emкостью не менее size, взятый из
+// наименьшего подходящего яруса sync.Pool, либо свежевыделенный срез, если
+// size превышает самый большой ярус.
+func bufferPoolGet(size int) []byte {
+	for i, tierSize := range bufferPoolTiers {
+		if size <= tierSize {
+			bufPtr := bufferPools[i].Get().(*[]byte)
+			bufferPoolHits.Add(1)
+			return (*bufPtr)[:0]
+		}
+	}
+	bufferPoolMisses.Add(1)
+	return make([]byte, 0, size)
+}
+
+// bufferPoolPut возвращает буфер в наибольший ярус, чья емкость <= емкости
+// буфера, чтобы его мог переиспользовать следующий вызов bufferPoolGet. Буферы,
+// превышающие самый большой ярус, отбрасываются (не удерживаем лишнюю память),
+// как и буферы меньше самого малого яруса.
+func bufferPoolPut(buf []byte) {
+	capacity := cap(buf)
+	if capacity > bufferPoolTiers[len(bufferPoolTiers)-1] {
+		return
+	}
+	for i := len(bufferPoolTiers) - 1; i >= 0; i-- {
+		if capacity >= bufferPoolTiers[i] {
+			buf = buf[:0]
+			bufferPools[i].Put(&buf)
+			return
+		}
+	}
+}
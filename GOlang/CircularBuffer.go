@@ -0,0 +1,315 @@
+package main
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Ошибки, которые могут возникнуть при работе с кольцевым буфером
+var (
+	ErrCircularBufferEmpty = errors.New("circular buffer is empty")
+	ErrCircularBufferFull  = errors.New("circular buffer is full")
+)
+
+// CircularBuffer реализует FIFO фиксированной емкости поверх массива степени
+// двойки: head/tail маскируются через `& (cap-1)` вместо деления по модулю,
+// что дает безветвистый (branch-free) переход через конец буфера.
+// По умолчанию Push при заполненном буфере возвращает ErrCircularBufferFull;
+// включив overwrite через SetOverwrite(true), Push вместо ошибки вытесняет
+// самый старый элемент.
+// Zero-value для CircularBuffer не является валидным для использования,
+// используйте NewCircularBuffer().
+type CircularBuffer[T any] struct {
+	data      []T
+	head      int
+	tail      int
+	size      int
+	overwrite bool
+}
+
+// NewCircularBuffer создает кольцевой буфер заданной емкости (округляется
+// вверх до ближайшей степени двойки, минимум 1).
+func NewCircularBuffer[T any](capacity int) *CircularBuffer[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &CircularBuffer[T]{
+		data: make([]T, nextPowerOfTwo(capacity)),
+	}
+}
+
+// SetOverwrite включает или выключает вытеснение самого старого элемента
+// при Push в заполненный буфер.
+func (c *CircularBuffer[T]) SetOverwrite(overwrite bool) {
+	c.overwrite = overwrite
+}
+
+// Push добавляет элемент в конец буфера. Если буфер заполнен и overwrite
+// выключен, возвращает ErrCircularBufferFull. Если overwrite включен, самый
+// старый элемент вытесняется (его место занимает новый элемент).
+// Сложность: O(1).
+func (c *CircularBuffer[T]) Push(element T) error {
+	mask := len(c.data) - 1
+	if c.size == len(c.data) {
+		if !c.overwrite {
+			return ErrCircularBufferFull
+		}
+		c.head = (c.head + 1) & mask
+		c.size--
+	}
+	c.data[c.tail] = element
+	c.tail = (c.tail + 1) & mask
+	c.size++
+	return nil
+}
+
+// Pop удаляет и возвращает самый старый элемент буфера.
+// Сложность: O(1).
+func (c *CircularBuffer[T]) Pop() (T, error) {
+	if c.size == 0 {
+		var zero T
+		return zero, ErrCircularBufferEmpty
+	}
+	mask := len(c.data) - 1
+	value := c.data[c.head]
+	var zero T
+	c.data[c.head] = zero // не удерживаем ссылку на ушедший элемент
+	c.head = (c.head + 1) & mask
+	c.size--
+	return value, nil
+}
+
+// Peek возвращает самый старый элемент буфера, не удаляя его.
+// Сложность: O(1).
+func (c *CircularBuffer[T]) Peek() (T, error) {
+	if c.size == 0 {
+		var zero T
+		return zero, ErrCircularBufferEmpty
+	}
+	return c.data[c.head], nil
+}
+
+// PeekAt возвращает элемент на позиции offset от самого старого (0 — то же,
+// что и Peek), не удаляя его. Названо иначе, чем Peek(offset int), так как
+// это имя уже занято Peek() без параметров. Сложность: O(1).
+func (c *CircularBuffer[T]) PeekAt(offset int) (T, error) {
+	if offset < 0 || offset >= c.size {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	mask := len(c.data) - 1
+	return c.data[(c.head+offset)&mask], nil
+}
+
+// PushEvict добавляет элемент в конец буфера, как Push с включенным
+// SetOverwrite(true), независимо от текущего значения overwrite: если буфер
+// заполнен, вытесняет самый старый элемент и возвращает его вместе с true;
+// иначе возвращает нулевое значение и false. Сложность: O(1).
+func (c *CircularBuffer[T]) PushEvict(element T) (evicted T, hadEviction bool) {
+	mask := len(c.data) - 1
+	if c.size == len(c.data) {
+		evicted = c.data[c.head]
+		hadEviction = true
+		c.head = (c.head + 1) & mask
+		c.size--
+	}
+	c.data[c.tail] = element
+	c.tail = (c.tail + 1) & mask
+	c.size++
+	return evicted, hadEviction
+}
+
+// Full сообщает, заполнен ли буфер до текущей емкости.
+func (c *CircularBuffer[T]) Full() bool {
+	return c.size == len(c.data)
+}
+
+// Size возвращает текущее количество элементов.
+func (c *CircularBuffer[T]) Size() int {
+	return c.size
+}
+
+// Capacity возвращает максимальную емкость буфера.
+func (c *CircularBuffer[T]) Capacity() int {
+	return len(c.data)
+}
+
+// IsEmpty проверяет, пуст ли буфер.
+func (c *CircularBuffer[T]) IsEmpty() bool {
+	return c.size == 0
+}
+
+// Clear полностью очищает буфер, сохраняя текущую емкость.
+func (c *CircularBuffer[T]) Clear() {
+	c.data = make([]T, len(c.data))
+	c.head = 0
+	c.tail = 0
+	c.size = 0
+}
+
+// Clone создает глубокую копию буфера (та же емкость, overwrite и содержимое).
+func (c *CircularBuffer[T]) Clone() *CircularBuffer[T] {
+	newBuf := &CircularBuffer[T]{
+		data:      make([]T, len(c.data)),
+		overwrite: c.overwrite,
+	}
+	mask := len(c.data) - 1
+	for i := 0; i < c.size; i++ {
+		newBuf.data[i] = c.data[(c.head+i)&mask]
+	}
+	newBuf.tail = c.size & mask
+	newBuf.size = c.size
+	return newBuf
+}
+
+// Values возвращает содержимое буфера в логическом порядке (от самого
+// старого к самому новому элементу).
+func (c *CircularBuffer[T]) Values() []T {
+	values := make([]T, c.size)
+	mask := len(c.data) - 1
+	for i := 0; i < c.size; i++ {
+		values[i] = c.data[(c.head+i)&mask]
+	}
+	return values
+}
+
+// Resize меняет емкость буфера на newCap (округляется вверх до степени
+// двойки, минимум 1), сохраняя newCap самых новых элементов — если текущее
+// содержимое не помещается в новую емкость, самые старые элементы отбрасываются.
+func (c *CircularBuffer[T]) Resize(newCap int) {
+	values := c.Values()
+	if newCap < 1 {
+		newCap = 1
+	}
+	newCap = nextPowerOfTwo(newCap)
+	if len(values) > newCap {
+		values = values[len(values)-newCap:]
+	}
+
+	c.data = make([]T, newCap)
+	copy(c.data, values)
+	c.head = 0
+	c.tail = len(values) & (newCap - 1)
+	c.size = len(values)
+}
+
+// Print выводит содержимое буфера от самого старого к самому новому элементу.
+func (c *CircularBuffer[T]) Print() {
+	fmt.Print("Oldest -> [")
+	mask := len(c.data) - 1
+	for i := 0; i < c.size; i++ {
+		idx := (c.head + i) & mask
+		fmt.Print(c.data[idx])
+		if i != c.size-1 {
+			fmt.Print(", ")
+		}
+	}
+	fmt.Println("] <- Newest")
+}
+
+// Serialize сохраняет состояние буфера в бинарном формате (gob): емкость,
+// размер и элементы от самого старого к самому новому. Сознательно не пишет
+// head/tail и сырой backing-массив напрямую — это сделало бы формат
+// неоднозначным (head==tail означает и "пусто", и "полно" без отдельного
+// size), а текущий формат и так восстанавливается за O(size) через Push и
+// переносится на буфер другой емкости.
+func (c *CircularBuffer[T]) Serialize(w io.Writer) error {
+	enc := gob.NewEncoder(w)
+
+	if err := enc.Encode(len(c.data)); err != nil {
+		return err
+	}
+	if err := enc.Encode(c.size); err != nil {
+		return err
+	}
+
+	mask := len(c.data) - 1
+	for i := 0; i < c.size; i++ {
+		idx := (c.head + i) & mask
+		if err := enc.Encode(c.data[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize восстанавливает буфер из бинарного формата (gob).
+func (c *CircularBuffer[T]) Deserialize(r io.Reader) error {
+	dec := gob.NewDecoder(r)
+
+	var capacity int
+	if err := dec.Decode(&capacity); err != nil {
+		return err
+	}
+	var newSize int
+	if err := dec.Decode(&newSize); err != nil {
+		return err
+	}
+
+	c.data = make([]T, nextPowerOfTwo(capacity))
+	c.head = 0
+	c.tail = 0
+	c.size = 0
+
+	for i := 0; i < newSize; i++ {
+		var val T
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		if err := c.Push(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SerializeText сохраняет элементы в текстовом виде через пробел.
+// Формат: <capacity> <size>\n<элементы через пробел>\n
+func (c *CircularBuffer[T]) SerializeText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%d %d\n", len(c.data), c.size); err != nil {
+		return err
+	}
+
+	mask := len(c.data) - 1
+	for i := 0; i < c.size; i++ {
+		idx := (c.head + i) & mask
+		if _, err := fmt.Fprint(w, c.data[idx]); err != nil {
+			return err
+		}
+		if i != c.size-1 {
+			if _, err := fmt.Fprint(w, " "); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeserializeText восстанавливает буфер из текстового формата.
+func (c *CircularBuffer[T]) DeserializeText(r io.Reader) error {
+	var capacity, newSize int
+	if _, err := fmt.Fscan(r, &capacity, &newSize); err != nil {
+		return err
+	}
+
+	c.data = make([]T, nextPowerOfTwo(capacity))
+	c.head = 0
+	c.tail = 0
+	c.size = 0
+
+	for i := 0; i < newSize; i++ {
+		var val T
+		if _, err := fmt.Fscan(r, &val); err != nil {
+			return err
+		}
+		if err := c.Push(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Codec абстрагирует формат сериализации, используемый ForwardList, DoubleList
+// и FullBinaryTree. data передается как указатель при декодировании (как и в
+// encoding/gob и encoding/json) и как значение при кодировании.
+type Codec interface {
+	Name() string
+	Encode(w io.Writer, data any) error
+	Decode(r io.Reader, data any) error
+}
+
+// GobCodec кодирует данные с помощью encoding/gob — текущий формат по умолчанию.
+type GobCodec struct{}
+
+func (GobCodec) Name() string { return "gob" }
+
+func (GobCodec) Encode(w io.Writer, data any) error {
+	return gob.NewEncoder(w).Encode(data)
+}
+
+func (GobCodec) Decode(r io.Reader, data any) error {
+	return gob.NewDecoder(r).Decode(data)
+}
+
+// JSONCodec кодирует данные как JSON. Pretty включает форматирование с отступами.
+type JSONCodec struct {
+	Pretty bool
+}
+
+func (JSONCodec) Name() string { return "json" }
+
+func (c JSONCodec) Encode(w io.Writer, data any) error {
+	if c.Pretty {
+		out, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	}
+	return json.NewEncoder(w).Encode(data)
+}
+
+func (JSONCodec) Decode(r io.Reader, data any) error {
+	return json.NewDecoder(r).Decode(data)
+}
+
+// binaryMagic и binaryVersion идентифицируют поток BinaryCodec и позволяют
+// обнаружить несовместимый формат до попытки разбора данных.
+var binaryMagic = [4]byte{'G', 'L', 'B', '1'}
+
+const binaryVersion uint16 = 1
+
+// BinaryCodec — компактный бинарный формат: 4-байтная magic-строка, uint16
+// версия, количество элементов как varint, а затем для каждого элемента —
+// его длина как varint, за которой следует gob-закодированное тело элемента.
+// Такое обрамление позволяет декодеру пропустить поврежденный элемент и
+// продолжить со следующего, вместо того чтобы десинхронизироваться на весь
+// оставшийся поток. Работает только с данными, являющимися срезом (слайсом).
+type BinaryCodec struct{}
+
+func (BinaryCodec) Name() string { return "binary" }
+
+func (BinaryCodec) Encode(w io.Writer, data any) error {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice {
+		return errors.New("BinaryCodec: data must be a slice")
+	}
+
+	if _, err := w.Write(binaryMagic[:]); err != nil {
+		return err
+	}
+	verBuf := make([]byte, 2)
+	binary.BigEndian.PutUint16(verBuf, binaryVersion)
+	if _, err := w.Write(verBuf); err != nil {
+		return err
+	}
+
+	n := rv.Len()
+	varintBuf := make([]byte, binary.MaxVarintLen64)
+	cn := binary.PutUvarint(varintBuf, uint64(n))
+	if _, err := w.Write(varintBuf[:cn]); err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		var elemBuf bytes.Buffer
+		if err := gob.NewEncoder(&elemBuf).Encode(rv.Index(i).Interface()); err != nil {
+			return fmt.Errorf("BinaryCodec: encode element %d: %w", i, err)
+		}
+		ln := binary.PutUvarint(varintBuf, uint64(elemBuf.Len()))
+		if _, err := w.Write(varintBuf[:ln]); err != nil {
+			return err
+		}
+		if _, err := w.Write(elemBuf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (BinaryCodec) Decode(r io.Reader, data any) error {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("BinaryCodec: data must be a pointer to a slice")
+	}
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return err
+	}
+	if !bytes.Equal(magic, binaryMagic[:]) {
+		return errors.New("BinaryCodec: bad magic header")
+	}
+	verBuf := make([]byte, 2)
+	if _, err := io.ReadFull(r, verBuf); err != nil {
+		return err
+	}
+	if version := binary.BigEndian.Uint16(verBuf); version != binaryVersion {
+		return fmt.Errorf("BinaryCodec: unsupported version %d", version)
+	}
+
+	br := bufio.NewReader(r)
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return err
+	}
+
+	sliceType := rv.Elem().Type()
+	elemType := sliceType.Elem()
+	slice := reflect.MakeSlice(sliceType, 0, int(count))
+
+	for i := uint64(0); i < count; i++ {
+		elemLen, err := binary.ReadUvarint(br)
+		if err != nil {
+			return fmt.Errorf("BinaryCodec: read length of element %d: %w", i, err)
+		}
+		elemBuf := make([]byte, elemLen)
+		if _, err := io.ReadFull(br, elemBuf); err != nil {
+			return fmt.Errorf("BinaryCodec: read element %d: %w", i, err)
+		}
+		elemPtr := reflect.New(elemType)
+		if err := gob.NewDecoder(bytes.NewReader(elemBuf)).DecodeValue(elemPtr.Elem()); err != nil {
+			return fmt.Errorf("BinaryCodec: decode element %d: %w", i, err)
+		}
+		slice = reflect.Append(slice, elemPtr.Elem())
+	}
+
+	rv.Elem().Set(slice)
+	return nil
+}
+
+// DecodeError описывает ошибку декодирования одного элемента, собранную
+// DecodeLenient. Index — позиция элемента в потоке (с нуля); -1 означает
+// ошибку уровня заголовка/кадра, после которой продолжить чтение было нельзя.
+type DecodeError struct {
+	Index int
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("decode: %v", e.Err)
+	}
+	return fmt.Sprintf("decode element %d: %v", e.Index, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// DecodeLenient декодирует поток так же, как Decode, но не прерывается на
+// первом поврежденном элементе: формат BinaryCodec кодирует перед телом
+// каждого элемента его длину (см. комментарий к BinaryCodec), поэтому,
+// обнаружив, что тело элемента не разбирается через gob, декодер просто
+// пропускает ровно elemLen байт и продолжает со следующего элемента. data
+// получает частично восстановленный срез (без пропущенных элементов).
+// Возвращает по одной DecodeError на каждый пропущенный элемент; пустой
+// (nil) результат означает, что все элементы декодированы успешно. Ошибка
+// в самом кадре потока (magic/версия/длина не читается) не восстановима —
+// в этом случае возвращается единственная DecodeError с Index: -1, и
+// декодирование останавливается.
+func (BinaryCodec) DecodeLenient(r io.Reader, data any) []DecodeError {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return []DecodeError{{Index: -1, Err: errors.New("BinaryCodec: data must be a pointer to a slice")}}
+	}
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return []DecodeError{{Index: -1, Err: err}}
+	}
+	if !bytes.Equal(magic, binaryMagic[:]) {
+		return []DecodeError{{Index: -1, Err: errors.New("BinaryCodec: bad magic header")}}
+	}
+	verBuf := make([]byte, 2)
+	if _, err := io.ReadFull(r, verBuf); err != nil {
+		return []DecodeError{{Index: -1, Err: err}}
+	}
+	if version := binary.BigEndian.Uint16(verBuf); version != binaryVersion {
+		return []DecodeError{{Index: -1, Err: fmt.Errorf("BinaryCodec: unsupported version %d", version)}}
+	}
+
+	br := bufio.NewReader(r)
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return []DecodeError{{Index: -1, Err: err}}
+	}
+
+	sliceType := rv.Elem().Type()
+	elemType := sliceType.Elem()
+	slice := reflect.MakeSlice(sliceType, 0, int(count))
+
+	var errs []DecodeError
+	for i := uint64(0); i < count; i++ {
+		elemLen, err := binary.ReadUvarint(br)
+		if err != nil {
+			errs = append(errs, DecodeError{Index: int(i), Err: fmt.Errorf("read length of element %d: %w", i, err)})
+			break
+		}
+		elemBuf := make([]byte, elemLen)
+		if _, err := io.ReadFull(br, elemBuf); err != nil {
+			errs = append(errs, DecodeError{Index: int(i), Err: fmt.Errorf("read element %d: %w", i, err)})
+			break
+		}
+		elemPtr := reflect.New(elemType)
+		if err := gob.NewDecoder(bytes.NewReader(elemBuf)).DecodeValue(elemPtr.Elem()); err != nil {
+			errs = append(errs, DecodeError{Index: int(i), Err: fmt.Errorf("decode element %d: %w", i, err)})
+			continue
+		}
+		slice = reflect.Append(slice, elemPtr.Elem())
+	}
+
+	rv.Elem().Set(slice)
+	return errs
+}
+
+// EncodeWith сериализует содержимое списка через указанный Codec.
+func (l *ForwardList[T]) EncodeWith(w io.Writer, codec Codec) error {
+	values := make([]T, 0, l.size)
+	for current := l.head; current != nil; current = current.Next {
+		values = append(values, current.Data)
+	}
+	return codec.Encode(w, values)
+}
+
+// DecodeWith заменяет содержимое списка данными, прочитанными через указанный Codec.
+func (l *ForwardList[T]) DecodeWith(r io.Reader, codec Codec) error {
+	var values []T
+	if err := codec.Decode(r, &values); err != nil {
+		return err
+	}
+	l.Clear()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return nil
+}
+
+// EncodeWith сериализует содержимое списка через указанный Codec.
+func (l *DoubleList[T]) EncodeWith(w io.Writer, codec Codec) error {
+	values := make([]T, 0, l.size)
+	for current := l.head; current != nil; current = current.Next {
+		values = append(values, current.Data)
+	}
+	return codec.Encode(w, values)
+}
+
+// DecodeWith заменяет содержимое списка данными, прочитанными через указанный Codec.
+func (l *DoubleList[T]) DecodeWith(r io.Reader, codec Codec) error {
+	var values []T
+	if err := codec.Decode(r, &values); err != nil {
+		return err
+	}
+	l.Clear()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return nil
+}
+
+// EncodeWith сериализует дерево через указанный Codec. BinaryCodec не
+// поддерживается, так как дерево не является срезом — используйте GobCodec
+// или JSONCodec.
+func (t *FullBinaryTree[T]) EncodeWith(w io.Writer, codec Codec) error {
+	return codec.Encode(w, t.root)
+}
+
+// DecodeWith заменяет дерево данными, прочитанными через указанный Codec.
+func (t *FullBinaryTree[T]) DecodeWith(r io.Reader, codec Codec) error {
+	var root *node[T]
+	if err := codec.Decode(r, &root); err != nil {
+		return err
+	}
+	t.root = root
+	t.size = countTreeNodes(root)
+	return nil
+}
+
+// TextOptions настраивает текстовый preorder-формат SerializeTextTo. Нулевое
+// значение дает те же разделитель и маркер отсутствующего узла, что и
+// SerializeText (пробел и treeNullToken), но без заголовка размера.
+type TextOptions struct {
+	// Delimiter разделяет токены. Пустая строка означает " " (как в SerializeText).
+	Delimiter string
+	// NilMarker — токен, которым обозначается отсутствующий ребенок. Пустая
+	// строка означает treeNullToken (как в SerializeText).
+	NilMarker string
+	// EmitSize, если true, пишет t.size первым токеном перед самим деревом
+	// (как в SerializeText). По умолчанию заголовок размера не пишется.
+	EmitSize bool
+}
+
+// SerializeTextTo сохраняет дерево в текстовом preorder-формате, как
+// SerializeText, но с настраиваемыми разделителем, маркером отсутствующего
+// узла и опциональным заголовком размера — пригодится, когда формат должен
+// встроиться во внешний парсер, ожидающий иной разделитель или маркер.
+func (t *FullBinaryTree[T]) SerializeTextTo(out io.Writer, opts TextOptions) error {
+	delim := opts.Delimiter
+	if delim == "" {
+		delim = " "
+	}
+	nilMarker := opts.NilMarker
+	if nilMarker == "" {
+		nilMarker = treeNullToken
+	}
+	if opts.EmitSize {
+		if _, err := fmt.Fprintf(out, "%d%s", t.size, delim); err != nil {
+			return err
+		}
+	}
+	return serializeTextToHelper(t.root, out, delim, nilMarker)
+}
+
+func serializeTextToHelper[T any](n *node[T], out io.Writer, delim, nilMarker string) error {
+	if n == nil {
+		_, err := fmt.Fprint(out, nilMarker+delim)
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "%v%s", n.Data, delim); err != nil {
+		return err
+	}
+	if err := serializeTextToHelper(n.Left, out, delim, nilMarker); err != nil {
+		return err
+	}
+	return serializeTextToHelper(n.Right, out, delim, nilMarker)
+}
+
+func countTreeNodes[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + countTreeNodes(n.Left) + countTreeNodes(n.Right)
+}
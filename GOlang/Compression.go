@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// CompressionCodec перечисляет алгоритмы сжатия, поддерживаемые
+// Serialize/DeserializeCompressed. Snappy и Zstd объявлены для совместимости
+// сигнатуры с внешними кодеками, но в этой сборке недоступны: ни один из их
+// пакетов не завезен (в дереве нет go.mod/vendor), поэтому они возвращают
+// явную ошибку вместо молчаливого отказа.
+type CompressionCodec byte
+
+const (
+	CompressionNone CompressionCodec = iota
+	CompressionGzip
+	CompressionSnappy
+	CompressionZstd
+)
+
+// compressionMagic и версия заголовка, который предшествует сжатому телу.
+var compressionMagic = [4]byte{'C', 'M', 'P', '1'}
+
+func (c CompressionCodec) newWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case CompressionNone:
+		return nopWriteCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionSnappy, CompressionZstd:
+		return nil, fmt.Errorf("CompressionCodec %d: external dependency not vendored in this build", c)
+	default:
+		return nil, fmt.Errorf("CompressionCodec %d: unknown codec", c)
+	}
+}
+
+func (c CompressionCodec) newReader(r io.Reader) (io.Reader, error) {
+	switch c {
+	case CompressionNone:
+		return r, nil
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionSnappy, CompressionZstd:
+		return nil, fmt.Errorf("CompressionCodec %d: external dependency not vendored in this build", c)
+	default:
+		return nil, fmt.Errorf("CompressionCodec %d: unknown codec", c)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// serializeCompressedTo записывает кадр "magic + codec ID + длина несжатых
+// данных + CRC32" перед сжатым (через codec) результатом encode, так что
+// Deserialize* может определить кодек и проверить целостность автоматически.
+func serializeCompressedTo(w io.Writer, codec CompressionCodec, encode func(io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := encode(&buf); err != nil {
+		return err
+	}
+	uncompressed := buf.Bytes()
+	checksum := crc32.ChecksumIEEE(uncompressed)
+
+	if _, err := w.Write(compressionMagic[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{byte(codec)}); err != nil {
+		return err
+	}
+	header := make([]byte, 12)
+	binary.BigEndian.PutUint64(header[0:8], uint64(len(uncompressed)))
+	binary.BigEndian.PutUint32(header[8:12], checksum)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	cw, err := codec.newWriter(w)
+	if err != nil {
+		return err
+	}
+	if _, err := cw.Write(uncompressed); err != nil {
+		return err
+	}
+	return cw.Close()
+}
+
+// deserializeCompressedFrom читает кадр, записанный serializeCompressedTo,
+// проверяет, что кодек в потоке совпадает с ожидаемым, распаковывает данные,
+// сверяет CRC32 и передает результат в decode.
+func deserializeCompressedFrom(r io.Reader, expected CompressionCodec, decode func(io.Reader) error) error {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return err
+	}
+	if !bytes.Equal(magic, compressionMagic[:]) {
+		return errors.New("compressed stream: bad magic header")
+	}
+
+	codecByte := make([]byte, 1)
+	if _, err := io.ReadFull(r, codecByte); err != nil {
+		return err
+	}
+	codec := CompressionCodec(codecByte[0])
+	if codec != expected {
+		return fmt.Errorf("compressed stream: codec %d does not match requested codec %d", codec, expected)
+	}
+
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+	uncompressedLen := binary.BigEndian.Uint64(header[0:8])
+	expectedChecksum := binary.BigEndian.Uint32(header[8:12])
+
+	cr, err := codec.newReader(r)
+	if err != nil {
+		return err
+	}
+	uncompressed := make([]byte, uncompressedLen)
+	if _, err := io.ReadFull(cr, uncompressed); err != nil {
+		return err
+	}
+
+	if crc32.ChecksumIEEE(uncompressed) != expectedChecksum {
+		return errors.New("compressed stream: checksum mismatch")
+	}
+	return decode(bytes.NewReader(uncompressed))
+}
+
+// SerializeCompressed сериализует массив в gob, сжимает результат codec'ом
+// и оборачивает его в кадр с magic-заголовком, длиной и CRC32.
+func (a *Array[T]) SerializeCompressed(w io.Writer, codec CompressionCodec) error {
+	return serializeCompressedTo(w, codec, a.Serialize)
+}
+
+// DeserializeCompressed читает поток, записанный SerializeCompressed.
+func (a *Array[T]) DeserializeCompressed(r io.Reader, codec CompressionCodec) error {
+	return deserializeCompressedFrom(r, codec, a.Deserialize)
+}
+
+// SerializeCompressed сериализует стек в gob, сжимает результат codec'ом
+// и оборачивает его в кадр с magic-заголовком, длиной и CRC32.
+func (s *Stack[T]) SerializeCompressed(w io.Writer, codec CompressionCodec) error {
+	return serializeCompressedTo(w, codec, s.Serialize)
+}
+
+// DeserializeCompressed читает поток, записанный SerializeCompressed.
+func (s *Stack[T]) DeserializeCompressed(r io.Reader, codec CompressionCodec) error {
+	return deserializeCompressedFrom(r, codec, s.Deserialize)
+}
+
+// SerializeCompressed сериализует очередь в gob, сжимает результат codec'ом
+// и оборачивает его в кадр с magic-заголовком, длиной и CRC32.
+func (q *Queue[T]) SerializeCompressed(w io.Writer, codec CompressionCodec) error {
+	return serializeCompressedTo(w, codec, q.Serialize)
+}
+
+// DeserializeCompressed читает поток, записанный SerializeCompressed.
+func (q *Queue[T]) DeserializeCompressed(r io.Reader, codec CompressionCodec) error {
+	return deserializeCompressedFrom(r, codec, q.Deserialize)
+}
+
+// SerializeCompressed сериализует хеш-таблицу в gob, сжимает результат codec'ом
+// и оборачивает его в кадр с magic-заголовком, длиной и CRC32.
+func (ht *HashTable[K, V]) SerializeCompressed(w io.Writer, codec CompressionCodec) error {
+	return serializeCompressedTo(w, codec, ht.Serialize)
+}
+
+// DeserializeCompressed читает поток, записанный SerializeCompressed.
+func (ht *HashTable[K, V]) DeserializeCompressed(r io.Reader, codec CompressionCodec) error {
+	return deserializeCompressedFrom(r, codec, ht.Deserialize)
+}
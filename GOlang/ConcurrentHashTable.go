@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// Управляющие байты control-массива ConcurrentHashTable, в духе SwissTable:
+// пустой слот, удаленный слот (tombstone), или старшие 7 бит хеша ключа для
+// занятого слота (значение в диапазоне [0, 0x7F], поэтому никогда не совпадает
+// с маркерами Empty/Deleted).
+const (
+	chtEmpty   byte = 0x80
+	chtDeleted byte = 0xFE
+)
+
+// chtSlot хранит одну пару ключ-значение. Слоты никогда не изменяются на
+// месте — любое обновление публикует новый *chtSlot через atomic.Pointer,
+// поэтому читатели, загрузившие указатель, всегда видят согласованную пару.
+type chtSlot[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// chtTable — один "снимок" плоского backing-массива: control-байты и слоты.
+// rehash строит новую chtTable и целиком заменяет указатель на нее, поэтому
+// читатели никогда не видят таблицу в процессе изменения размера.
+type chtTable[K comparable, V any] struct {
+	// ctrl хранится как []atomic.Uint32 (atomic.Uint8 в sync/atomic не
+	// существует): каждый элемент несет один control-байт в младших 8 битах,
+	// но читается/пишется атомарно, как и раньше.
+	ctrl  []atomic.Uint32
+	slots []atomic.Pointer[chtSlot[K, V]]
+	mask  uint64
+}
+
+func newCHTTable[K comparable, V any](capacity int) *chtTable[K, V] {
+	t := &chtTable[K, V]{
+		ctrl:  make([]atomic.Uint32, capacity),
+		slots: make([]atomic.Pointer[chtSlot[K, V]], capacity),
+		mask:  uint64(capacity - 1),
+	}
+	for i := range t.ctrl {
+		t.ctrl[i].Store(uint32(chtEmpty))
+	}
+	return t
+}
+
+// ConcurrentHashTable — вариант HashTable с лок-фри чтением.
+// Get/Find никогда не берут мьютекс: они атомарно загружают текущий снимок
+// таблицы и пробируют его control-байты и слоты. Insert/Remove/rehash
+// сериализуются под одним mu (single-writer), который публикует новые
+// данные через atomic.Pointer/atomic.Uint32, так что писатель и читатели
+// никогда не гонятся за одним и тем же полем.
+type ConcurrentHashTable[K comparable, V any] struct {
+	mu     sync.Mutex
+	data   atomic.Pointer[chtTable[K, V]]
+	size   atomic.Int64
+	hasher Hasher[K]
+}
+
+// Hasher — подключаемая стратегия хеширования ключей ConcurrentHashTable.
+// По умолчанию используется chtHash (FNV-1a от fmt.Sprintf("%v", key)),
+// которая годится для любого comparable-типа, но на каждый вызов выделяет
+// строку. Для горячих путей с известным типом ключа (string, int, ...)
+// зарегистрируйте через SetHasher реализацию без этой аллокации.
+type Hasher[K any] interface {
+	Hash(key K) uint64
+}
+
+// NewConcurrentHashTable создает новую конкурентную хеш-таблицу.
+// initialCapacity опционален (по умолчанию 16) и округляется вверх до степени двойки.
+func NewConcurrentHashTable[K comparable, V any](initialCapacity ...int) *ConcurrentHashTable[K, V] {
+	cap := 16
+	if len(initialCapacity) > 0 && initialCapacity[0] > 0 {
+		cap = nextPowerOfTwo(initialCapacity[0])
+	}
+	ht := &ConcurrentHashTable[K, V]{}
+	ht.data.Store(newCHTTable[K, V](cap))
+	return ht
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// chtHash вычисляет 64-битный хеш ключа (FNV-1a от строкового представления,
+// как и в HashTable.hash). Используется по умолчанию, пока не зарегистрирован
+// собственный Hasher через SetHasher.
+func chtHash[K comparable](key K) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(fmt.Sprintf("%v", key)))
+	return h.Sum64()
+}
+
+// SetHasher регистрирует h как стратегию хеширования ключей, заменяя
+// chtHash по умолчанию. Не конкурентно-безопасна относительно Insert/Remove/
+// rehash — вызывать до начала конкурентного использования таблицы.
+func (ht *ConcurrentHashTable[K, V]) SetHasher(h Hasher[K]) {
+	ht.hasher = h
+}
+
+// hash вычисляет хеш ключа через зарегистрированный Hasher, либо через
+// chtHash, если SetHasher не вызывался.
+func (ht *ConcurrentHashTable[K, V]) hash(key K) uint64 {
+	if ht.hasher != nil {
+		return ht.hasher.Hash(key)
+	}
+	return chtHash(key)
+}
+
+// splitHash делит хеш на индекс начального бакета и 7-битный тег для control-байта.
+func splitHash(hash uint64, mask uint64) (startIdx uint64, tag byte) {
+	return hash & mask, byte(hash >> 57)
+}
+
+// Get читает значение по ключу без блокировок (лок-фри путь чтения).
+func (ht *ConcurrentHashTable[K, V]) Get(key K) (V, bool) {
+	tbl := ht.data.Load()
+	idx, tag := splitHash(ht.hash(key), tbl.mask)
+
+	for i := uint64(0); i <= tbl.mask; i++ {
+		pos := (idx + i) & tbl.mask
+		c := byte(tbl.ctrl[pos].Load())
+		if c == chtEmpty {
+			break
+		}
+		if c == tag {
+			if slot := tbl.slots[pos].Load(); slot != nil && slot.Key == key {
+				return slot.Value, true
+			}
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Find проверяет наличие ключа без блокировок.
+func (ht *ConcurrentHashTable[K, V]) Find(key K) bool {
+	_, ok := ht.Get(key)
+	return ok
+}
+
+// Insert вставляет или обновляет пару ключ-значение. Сериализуется под mu;
+// публикует изменения через atomic-операции, чтобы конкурентные Get/Find
+// никогда не видели частично записанный слот.
+func (ht *ConcurrentHashTable[K, V]) Insert(key K, value V) {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+
+	if ht.loadFactorLocked() > 0.75 {
+		ht.rehashLocked()
+	}
+	ht.insertLocked(key, value)
+}
+
+// insertLocked выполняет саму вставку в текущий снимок таблицы. Вызывающий
+// должен удерживать mu.
+func (ht *ConcurrentHashTable[K, V]) insertLocked(key K, value V) {
+	tbl := ht.data.Load()
+	idx, tag := splitHash(ht.hash(key), tbl.mask)
+
+	firstTombstone := -1
+	for i := uint64(0); i <= tbl.mask; i++ {
+		pos := (idx + i) & tbl.mask
+		c := byte(tbl.ctrl[pos].Load())
+
+		switch {
+		case c == chtEmpty:
+			target := pos
+			if firstTombstone >= 0 {
+				target = uint64(firstTombstone)
+			}
+			tbl.slots[target].Store(&chtSlot[K, V]{Key: key, Value: value})
+			tbl.ctrl[target].Store(uint32(tag))
+			ht.size.Add(1)
+			return
+		case c == chtDeleted:
+			if firstTombstone < 0 {
+				firstTombstone = int(pos)
+			}
+		case c == tag:
+			if slot := tbl.slots[pos].Load(); slot != nil && slot.Key == key {
+				tbl.slots[pos].Store(&chtSlot[K, V]{Key: key, Value: value})
+				return
+			}
+		}
+	}
+
+	// Не должно происходить при соблюдении порога LoadFactor, но на случай
+	// полностью занятой таблицы (например, после множества tombstone) — рехешируем и повторяем.
+	ht.rehashLocked()
+	ht.insertLocked(key, value)
+}
+
+// Remove удаляет элемент по ключу, оставляя tombstone для сохранения цепочки
+// пробирования. Сериализуется под mu.
+func (ht *ConcurrentHashTable[K, V]) Remove(key K) error {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+
+	tbl := ht.data.Load()
+	idx, tag := splitHash(ht.hash(key), tbl.mask)
+
+	for i := uint64(0); i <= tbl.mask; i++ {
+		pos := (idx + i) & tbl.mask
+		c := byte(tbl.ctrl[pos].Load())
+		if c == chtEmpty {
+			break
+		}
+		if c == tag {
+			if slot := tbl.slots[pos].Load(); slot != nil && slot.Key == key {
+				tbl.ctrl[pos].Store(uint32(chtDeleted))
+				tbl.slots[pos].Store(nil)
+				ht.size.Add(-1)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("key not found")
+}
+
+// rehashLocked удваивает емкость таблицы и переносит живые элементы в новый
+// снимок, который публикуется одним atomic-сохранением. Вызывающий должен
+// удерживать mu.
+func (ht *ConcurrentHashTable[K, V]) rehashLocked() {
+	old := ht.data.Load()
+	newCap := int(old.mask+1) * 2
+	newTbl := newCHTTable[K, V](newCap)
+
+	for i := range old.ctrl {
+		c := byte(old.ctrl[i].Load())
+		if c == chtEmpty || c == chtDeleted {
+			continue
+		}
+		slot := old.slots[i].Load()
+		if slot == nil {
+			continue
+		}
+		idx, tag := splitHash(ht.hash(slot.Key), newTbl.mask)
+		for j := uint64(0); j <= newTbl.mask; j++ {
+			pos := (idx + j) & newTbl.mask
+			if byte(newTbl.ctrl[pos].Load()) == chtEmpty {
+				newTbl.slots[pos].Store(slot)
+				newTbl.ctrl[pos].Store(uint32(tag))
+				break
+			}
+		}
+	}
+	ht.data.Store(newTbl)
+}
+
+// GetSize возвращает приблизительное текущее количество элементов.
+func (ht *ConcurrentHashTable[K, V]) GetSize() int {
+	return int(ht.size.Load())
+}
+
+// GetBucketCount возвращает текущую емкость backing-массива.
+func (ht *ConcurrentHashTable[K, V]) GetBucketCount() int {
+	return int(ht.data.Load().mask + 1)
+}
+
+// IsEmpty проверяет, пуста ли таблица.
+func (ht *ConcurrentHashTable[K, V]) IsEmpty() bool {
+	return ht.GetSize() == 0
+}
+
+// LoadFactor вычисляет текущий коэффициент заполнения.
+func (ht *ConcurrentHashTable[K, V]) LoadFactor() float64 {
+	return ht.loadFactorLocked()
+}
+
+func (ht *ConcurrentHashTable[K, V]) loadFactorLocked() float64 {
+	tbl := ht.data.Load()
+	return float64(ht.size.Load()) / float64(tbl.mask+1)
+}
+
+// Clear полностью очищает таблицу, возвращая ее к емкости по умолчанию (16),
+// независимо от initialCapacity, переданной в конструктор.
+func (ht *ConcurrentHashTable[K, V]) Clear() {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+
+	ht.data.Store(newCHTTable[K, V](16))
+	ht.size.Store(0)
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Ошибки, которые могут возникнуть при работе с конкурентными вариантами
+// Queue/Stack.
+var (
+	ErrQueueFull = errors.New("queue is full")
+	ErrStackFull = errors.New("stack is full")
+)
+
+// ConcurrentQueue — потокобезопасная обертка над Queue: каждый метод берет
+// mu на время работы с обернутой очередью. Подходит, когда нужен просто
+// безопасный конкурентный доступ без блокирующего ожидания места/элементов —
+// для этого см. BlockingQueue.
+type ConcurrentQueue[T any] struct {
+	mu    sync.Mutex
+	queue *Queue[T]
+}
+
+// NewConcurrentQueue создает новую пустую конкурентную очередь.
+func NewConcurrentQueue[T any]() *ConcurrentQueue[T] {
+	return &ConcurrentQueue[T]{queue: NewQueue[T]()}
+}
+
+// Enqueue добавляет элемент в конец очереди.
+func (q *ConcurrentQueue[T]) Enqueue(element T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue.Enqueue(element)
+}
+
+// Dequeue удаляет элемент из начала очереди.
+// Возвращает ошибку, если очередь пуста.
+func (q *ConcurrentQueue[T]) Dequeue() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Dequeue()
+}
+
+// Front возвращает первый элемент очереди.
+func (q *ConcurrentQueue[T]) Front() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Front()
+}
+
+// Size возвращает текущий размер очереди.
+func (q *ConcurrentQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Size()
+}
+
+// IsEmpty проверяет, пуста ли очередь.
+func (q *ConcurrentQueue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.IsEmpty()
+}
+
+// Clear полностью очищает очередь.
+func (q *ConcurrentQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue.Clear()
+}
+
+// Serialize сохраняет снимок очереди в бинарном формате (gob), сделанный под mu.
+func (q *ConcurrentQueue[T]) Serialize(w io.Writer) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Serialize(w)
+}
+
+// Deserialize восстанавливает очередь из бинарного формата (gob) под mu.
+func (q *ConcurrentQueue[T]) Deserialize(r io.Reader) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Deserialize(r)
+}
+
+// ConcurrentStack — потокобезопасная обертка над Stack, аналогичная
+// ConcurrentQueue.
+type ConcurrentStack[T any] struct {
+	mu    sync.Mutex
+	stack *Stack[T]
+}
+
+// NewConcurrentStack создает новый пустой конкурентный стек.
+func NewConcurrentStack[T any]() *ConcurrentStack[T] {
+	return &ConcurrentStack[T]{stack: NewStack[T]()}
+}
+
+// Push помещает элемент на вершину стека.
+func (s *ConcurrentStack[T]) Push(element T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stack.Push(element)
+}
+
+// Pop удаляет элемент с вершины стека.
+// Возвращает ошибку, если стек пуст.
+func (s *ConcurrentStack[T]) Pop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Pop()
+}
+
+// Top возвращает элемент на вершине стека.
+func (s *ConcurrentStack[T]) Top() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Top()
+}
+
+// Size возвращает текущий размер стека.
+func (s *ConcurrentStack[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Size()
+}
+
+// IsEmpty проверяет, пуст ли стек.
+func (s *ConcurrentStack[T]) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.IsEmpty()
+}
+
+// Clear полностью очищает стек.
+func (s *ConcurrentStack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stack.Clear()
+}
+
+// Serialize сохраняет снимок стека в бинарном формате (gob), сделанный под mu.
+func (s *ConcurrentStack[T]) Serialize(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Serialize(w)
+}
+
+// Deserialize восстанавливает стек из бинарного формата (gob) под mu.
+func (s *ConcurrentStack[T]) Deserialize(r io.Reader) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Deserialize(r)
+}
+
+// BlockingQueue — очередь с опциональной вместимостью (0 или отрицательное
+// значение capacity означает неограниченную очередь, растущую как обычная
+// Queue) и блокирующими Put/Take, ожидающими места/элементов соответственно.
+// notFull/notEmpty — sync.Cond поверх одного mu: Enqueue сигналит notEmpty,
+// Dequeue сигналит notFull. Zero-value для BlockingQueue не является
+// валидным для использования, используйте NewBlockingQueue()/NewBoundedBlockingQueue().
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	queue    *Queue[T]
+	capacity int // <= 0 означает отсутствие ограничения
+}
+
+// NewBlockingQueue создает неограниченную блокирующую очередь.
+func NewBlockingQueue[T any]() *BlockingQueue[T] {
+	return NewBoundedBlockingQueue[T](0)
+}
+
+// NewBoundedBlockingQueue создает блокирующую очередь вместимостью capacity.
+// capacity <= 0 означает отсутствие ограничения (как NewBlockingQueue).
+func NewBoundedBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	bq := &BlockingQueue[T]{queue: NewQueue[T](), capacity: capacity}
+	bq.notEmpty = sync.NewCond(&bq.mu)
+	bq.notFull = sync.NewCond(&bq.mu)
+	return bq
+}
+
+// waitWithContext блокирует на cond.Wait(), но прерывается при отмене ctx.
+// context.AfterFunc регистрирует Broadcast по всем cond очереди при отмене
+// ctx — это будит все ожидающие горутины (включая ту, что ждет на другом
+// cond), чтобы каждая могла перепроверить свое условие и ctx.Err().
+func (q *BlockingQueue[T]) waitWithContext(ctx context.Context, cond *sync.Cond) {
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.notEmpty.Broadcast()
+		q.notFull.Broadcast()
+	})
+	defer stop()
+	cond.Wait()
+}
+
+// Put добавляет элемент в очередь, блокируясь, пока не появится место
+// (в ограниченном режиме), либо пока ctx не будет отменен.
+func (q *BlockingQueue[T]) Put(ctx context.Context, element T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.capacity > 0 && q.queue.Size() >= q.capacity {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		q.waitWithContext(ctx, q.notFull)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	q.queue.Enqueue(element)
+	q.notEmpty.Signal()
+	return nil
+}
+
+// Take удаляет и возвращает первый элемент очереди, блокируясь, пока очередь
+// не перестанет быть пустой, либо пока ctx не будет отменен.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.queue.IsEmpty() {
+		if err := ctx.Err(); err != nil {
+			var zero T
+			return zero, err
+		}
+		q.waitWithContext(ctx, q.notEmpty)
+	}
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	v, err := q.queue.Front()
+	if err != nil {
+		return v, err
+	}
+	if err := q.queue.Dequeue(); err != nil {
+		return v, err
+	}
+	q.notFull.Signal()
+	return v, nil
+}
+
+// Offer — неблокирующий вариант Put: сразу возвращает false, если очередь
+// ограничена и заполнена, иначе добавляет элемент и возвращает true.
+func (q *BlockingQueue[T]) Offer(element T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.capacity > 0 && q.queue.Size() >= q.capacity {
+		return false
+	}
+	q.queue.Enqueue(element)
+	q.notEmpty.Signal()
+	return true
+}
+
+// Poll — неблокирующий вариант Take: сразу возвращает false, если очередь пуста.
+func (q *BlockingQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	v, err := q.queue.Front()
+	if err != nil {
+		var zero T
+		return zero, false
+	}
+	_ = q.queue.Dequeue()
+	q.notFull.Signal()
+	return v, true
+}
+
+// Size возвращает текущий размер очереди.
+func (q *BlockingQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Size()
+}
+
+// IsEmpty проверяет, пуста ли очередь.
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.IsEmpty()
+}
+
+// Clear полностью очищает очередь и будит все горутины, ожидающие в Put
+// (место появилось) — ожидающие в Take продолжат ждать, так как очередь
+// остается пустой.
+func (q *BlockingQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue.Clear()
+	q.notFull.Broadcast()
+}
+
+// Serialize сохраняет снимок очереди в бинарном формате (gob), сделанный под mu.
+func (q *BlockingQueue[T]) Serialize(w io.Writer) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Serialize(w)
+}
+
+// Deserialize восстанавливает очередь из бинарного формата (gob) под mu и
+// будит ожидающих в Take, так как в очереди могли появиться элементы.
+func (q *BlockingQueue[T]) Deserialize(r io.Reader) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.queue.Deserialize(r); err != nil {
+		return err
+	}
+	q.notEmpty.Broadcast()
+	return nil
+}
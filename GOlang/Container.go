@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Container — сквозной интерфейс, которому удовлетворяет каждая коллекция
+// пакета (Array, ForwardList, DoubleList, Stack, Queue, HashTable через пары
+// HashEntry, FullBinaryTree). Он не заменяет специфичные для контейнера методы
+// (GetSize/IsEmpty и т.д.), а даёт единый набор операций для написания общих
+// тестов и обобщенного кода, которому не важна конкретная структура данных.
+type Container[T any] interface {
+	Empty() bool
+	Size() int
+	Clear()
+	Values() []T
+	String() string
+}
+
+// ForwardEnumerator — однонаправленный курсор обхода контейнера с
+// возможностью вернуться в начальное положение. Соответствует Iterator,
+// дополненному Reset(); названо иначе, чем ForwardIterator[T], так как это
+// имя уже занято конкретным итератором ForwardList.
+type ForwardEnumerator[T any] interface {
+	Iterator[T]
+	Reset()
+}
+
+// BidirectionalEnumerator расширяет ForwardEnumerator движением назад (Prev).
+// Реализуется курсорами контейнеров, где обратный обход осмыслен: Array,
+// DoubleList, FullBinaryTree (по снимку in-order обхода).
+type BidirectionalEnumerator[T any] interface {
+	ForwardEnumerator[T]
+	Prev()
+}
+
+// valuesFromIterator собирает оставшиеся элементы итератора в срез, не
+// трогая уже пройденную часть — используется для реализации Values() без
+// дублирования обхода в каждом контейнере.
+func valuesFromIterator[T any](it Iterator[T]) []T {
+	result := make([]T, 0)
+	Each(it, func(v T) {
+		result = append(result, v)
+	})
+	return result
+}
+
+// stringFromValues форматирует срез значений как "prefix[v1, v2, ...]suffix" —
+// общий формат, которому следуют String() всех контейнеров.
+func stringFromValues[T any](prefix string, values []T, suffix string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString("[")
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprint(&b, v)
+	}
+	b.WriteString("]")
+	b.WriteString(suffix)
+	return b.String()
+}
+
+// --- Array ---
+
+// Empty реализует Container.Empty (синоним IsEmpty для единого интерфейса).
+func (a *Array[T]) Empty() bool { return a.IsEmpty() }
+
+// Values возвращает копию содержимого массива в порядке от начала к концу.
+func (a *Array[T]) Values() []T { return valuesFromIterator[T](a.Begin()) }
+
+// String форматирует массив как "[v1, v2, ...]".
+func (a *Array[T]) String() string { return stringFromValues("", a.Values(), "") }
+
+// Iterator возвращает двунаправленный курсор обхода, начинающийся с первого элемента.
+func (a *Array[T]) Iterator() BidirectionalEnumerator[T] { return a.Begin() }
+
+// --- ForwardList ---
+
+// Empty реализует Container.Empty (синоним IsEmpty для единого интерфейса).
+func (l *ForwardList[T]) Empty() bool { return l.IsEmpty() }
+
+// Size реализует Container.Size (синоним GetSize для единого интерфейса).
+func (l *ForwardList[T]) Size() int { return l.GetSize() }
+
+// Values возвращает копию содержимого списка в порядке от головы к хвосту.
+func (l *ForwardList[T]) Values() []T { return valuesFromIterator[T](l.Begin()) }
+
+// String форматирует список как "[v1 -> v2 -> ...]".
+func (l *ForwardList[T]) String() string {
+	values := l.Values()
+	var b strings.Builder
+	b.WriteString("[")
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(" -> ")
+		}
+		fmt.Fprint(&b, v)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Iterator возвращает однонаправленный курсор обхода, начинающийся с головы списка.
+func (l *ForwardList[T]) Iterator() ForwardEnumerator[T] { return l.Begin() }
+
+// --- DoubleList ---
+
+// Empty реализует Container.Empty (синоним IsEmpty для единого интерфейса).
+func (l *DoubleList[T]) Empty() bool { return l.IsEmpty() }
+
+// Size реализует Container.Size (синоним GetSize для единого интерфейса).
+func (l *DoubleList[T]) Size() int { return l.GetSize() }
+
+// Values возвращает копию содержимого списка в порядке от головы к хвосту.
+func (l *DoubleList[T]) Values() []T { return valuesFromIterator[T](l.Begin()) }
+
+// String форматирует список как "[v1 <-> v2 <-> ...]".
+func (l *DoubleList[T]) String() string {
+	values := l.Values()
+	var b strings.Builder
+	b.WriteString("[")
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(" <-> ")
+		}
+		fmt.Fprint(&b, v)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Iterator возвращает двунаправленный курсор обхода, начинающийся с головы списка.
+func (l *DoubleList[T]) Iterator() BidirectionalEnumerator[T] { return l.Begin() }
+
+// --- Stack ---
+
+// Empty реализует Container.Empty (синоним IsEmpty для единого интерфейса).
+func (s *Stack[T]) Empty() bool { return s.IsEmpty() }
+
+// Values возвращает копию содержимого стека от вершины к дну.
+func (s *Stack[T]) Values() []T { return valuesFromIterator[T](s.Begin()) }
+
+// String форматирует стек как "Top -> [v1, v2, ...] <- Bottom".
+func (s *Stack[T]) String() string { return stringFromValues("Top -> ", s.Values(), " <- Bottom") }
+
+// Iterator возвращает однонаправленный курсор обхода от вершины к дну.
+func (s *Stack[T]) Iterator() ForwardEnumerator[T] { return s.Begin() }
+
+// --- Queue ---
+
+// Empty реализует Container.Empty (синоним IsEmpty для единого интерфейса).
+func (q *Queue[T]) Empty() bool { return q.IsEmpty() }
+
+// Values возвращает копию содержимого очереди от начала к концу.
+func (q *Queue[T]) Values() []T { return valuesFromIterator[T](q.Begin()) }
+
+// String форматирует очередь как "Front -> [v1, v2, ...] <- Back".
+func (q *Queue[T]) String() string { return stringFromValues("Front -> ", q.Values(), " <- Back") }
+
+// Iterator возвращает однонаправленный курсор обхода от начала к концу.
+func (q *Queue[T]) Iterator() ForwardEnumerator[T] { return q.Begin() }
+
+// --- HashTable ---
+
+// Empty реализует Container.Empty (синоним IsEmpty для единого интерфейса).
+func (ht *HashTable[K, V]) Empty() bool { return ht.IsEmpty() }
+
+// Size реализует Container.Size (синоним GetSize для единого интерфейса).
+func (ht *HashTable[K, V]) Size() int { return ht.GetSize() }
+
+// Values возвращает копию содержимого таблицы как пары HashEntry{Key, Value}.
+// Порядок не гарантирован (зависит от порядка бакетов).
+func (ht *HashTable[K, V]) Values() []HashEntry[K, V] {
+	return valuesFromIterator[HashEntry[K, V]](ht.Begin())
+}
+
+// String форматирует таблицу как "{k1: v1, k2: v2, ...}".
+func (ht *HashTable[K, V]) String() string {
+	values := ht.Values()
+	var b strings.Builder
+	b.WriteString("{")
+	for i, entry := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%v: %v", entry.Key, entry.Value)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// Iterator возвращает однонаправленный курсор обхода по парам ключ-значение.
+func (ht *HashTable[K, V]) Iterator() ForwardEnumerator[HashEntry[K, V]] { return ht.Begin() }
+
+// --- FullBinaryTree ---
+
+// Empty реализует Container.Empty (синоним IsEmpty для единого интерфейса).
+func (t *FullBinaryTree[T]) Empty() bool { return t.IsEmpty() }
+
+// Size реализует Container.Size (синоним GetSize для единого интерфейса).
+func (t *FullBinaryTree[T]) Size() int { return t.GetSize() }
+
+// Values возвращает элементы дерева в порядке in-order обхода.
+func (t *FullBinaryTree[T]) Values() []T { return valuesFromIterator[T](t.Begin()) }
+
+// String форматирует дерево как "[v1, v2, ...]" в порядке in-order обхода.
+func (t *FullBinaryTree[T]) String() string { return stringFromValues("", t.Values(), "") }
+
+// Iterator возвращает двунаправленный курсор обхода по снимку in-order обхода.
+func (t *FullBinaryTree[T]) Iterator() BidirectionalEnumerator[T] { return t.Begin() }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/gob"
 	"fmt"
 	"io"
@@ -285,8 +286,11 @@ func (l *DoubleList[T]) PrintReverse() {
 	fmt.Println("]")
 }
 
+// SerializeBinary кодирует gob во временный буфер из bufferpool, а не
+// напрямую в out, чтобы повторные сериализации не аллоцировали буфер заново.
 func (l *DoubleList[T]) SerializeBinary(out io.Writer) error {
-	enc := gob.NewEncoder(out)
+	buf := bytes.NewBuffer(bufferPoolGet(l.size * 16))
+	enc := gob.NewEncoder(buf)
 	if err := enc.Encode(l.size); err != nil {
 		return err
 	}
@@ -297,6 +301,10 @@ func (l *DoubleList[T]) SerializeBinary(out io.Writer) error {
 		}
 		current = current.Next
 	}
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	bufferPoolPut(buf.Bytes())
 	return nil
 }
 
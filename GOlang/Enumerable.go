@@ -0,0 +1,361 @@
+package main
+
+// Iterator — сквозной интерфейс стейтфул-итератора прямого обхода,
+// реализуемый итераторами Array, ForwardList, DoubleList, Queue, Stack,
+// HashTable и FullBinaryTree. В отличие от Range/All (колбэк-стиль), он
+// позволяет вести обход вручную, не начиная каждый раз с головы контейнера.
+type Iterator[T any] interface {
+	Valid() bool
+	Value() *T
+	Next()
+	Index() int
+}
+
+// ReverseIterator расширяет Iterator возможностью двигаться назад.
+// Реализуется итераторами контейнеров, где обратный обход осмыслен:
+// Array, DoubleList, FullBinaryTree (по снимку in-order обхода).
+type ReverseIterator[T any] interface {
+	Iterator[T]
+	Prev()
+}
+
+// IteratorWithIndex расширяет Iterator произвольным позиционированием —
+// для контейнеров, предоставляющих O(1) индексированный доступ (Array,
+// FullBinaryTree через снимок обхода).
+type IteratorWithIndex[T any] interface {
+	Iterator[T]
+	Seek(i int)
+}
+
+// Each вызывает fn для каждого значения, оставшегося в итераторе.
+func Each[T any](it Iterator[T], fn func(T)) {
+	for it.Valid() {
+		fn(*it.Value())
+		it.Next()
+	}
+}
+
+// Map применяет fn к каждому значению итератора и возвращает срез результатов.
+func Map[T, U any](it Iterator[T], fn func(T) U) []U {
+	result := make([]U, 0)
+	for it.Valid() {
+		result = append(result, fn(*it.Value()))
+		it.Next()
+	}
+	return result
+}
+
+// Select возвращает срез значений, для которых fn вернула true.
+func Select[T any](it Iterator[T], fn func(T) bool) []T {
+	result := make([]T, 0)
+	for it.Valid() {
+		v := *it.Value()
+		if fn(v) {
+			result = append(result, v)
+		}
+		it.Next()
+	}
+	return result
+}
+
+// Any сообщает, нашелся ли хотя бы один элемент, для которого fn вернула true.
+func Any[T any](it Iterator[T], fn func(T) bool) bool {
+	for it.Valid() {
+		if fn(*it.Value()) {
+			return true
+		}
+		it.Next()
+	}
+	return false
+}
+
+// All сообщает, вернула ли fn true для всех оставшихся элементов.
+func All[T any](it Iterator[T], fn func(T) bool) bool {
+	for it.Valid() {
+		if !fn(*it.Value()) {
+			return false
+		}
+		it.Next()
+	}
+	return true
+}
+
+// Find возвращает первый элемент, для которого fn вернула true, и true.
+// Если такого элемента нет, возвращает нулевое значение и false.
+func Find[T any](it Iterator[T], fn func(T) bool) (T, bool) {
+	for it.Valid() {
+		v := *it.Value()
+		if fn(v) {
+			return v, true
+		}
+		it.Next()
+	}
+	var zero T
+	return zero, false
+}
+
+// Reduce сворачивает оставшиеся элементы итератора в единое значение,
+// начиная с init и последовательно применяя fn(накопитель, элемент).
+func Reduce[T, U any](it Iterator[T], init U, fn func(U, T) U) U {
+	acc := init
+	for it.Valid() {
+		acc = fn(acc, *it.Value())
+		it.Next()
+	}
+	return acc
+}
+
+// --- ArrayIterator ---
+
+// ArrayIterator — индексированный двунаправленный итератор Array.
+type ArrayIterator[T any] struct {
+	arr *Array[T]
+	idx int
+}
+
+// Begin возвращает итератор, указывающий на первый элемент массива.
+func (a *Array[T]) Begin() *ArrayIterator[T] {
+	return &ArrayIterator[T]{arr: a, idx: 0}
+}
+
+// End возвращает недействительный итератор, представляющий позицию "за последним элементом".
+func (a *Array[T]) End() *ArrayIterator[T] {
+	return &ArrayIterator[T]{arr: a, idx: a.size}
+}
+
+func (it *ArrayIterator[T]) Valid() bool {
+	return it.idx >= 0 && it.idx < it.arr.size
+}
+
+func (it *ArrayIterator[T]) Value() *T {
+	if !it.Valid() {
+		return nil
+	}
+	return &it.arr.data[it.idx]
+}
+
+func (it *ArrayIterator[T]) Next() { it.idx++ }
+func (it *ArrayIterator[T]) Prev() { it.idx-- }
+func (it *ArrayIterator[T]) Index() int { return it.idx }
+func (it *ArrayIterator[T]) Seek(i int) { it.idx = i }
+
+// Reset возвращает итератор к первому элементу массива.
+func (it *ArrayIterator[T]) Reset() { it.idx = 0 }
+
+// --- QueueIterator ---
+
+// QueueIterator — однонаправленный итератор Queue (от Front к Back),
+// так как Queue построена на односвязном списке. Хранит ссылку на очередь,
+// чтобы Reset() мог вернуться к началу без повторного вызова Begin().
+type QueueIterator[T any] struct {
+	queue *Queue[T]
+	node  *qNode[T]
+	idx   int
+}
+
+// Begin возвращает итератор, указывающий на первый элемент очереди.
+func (q *Queue[T]) Begin() *QueueIterator[T] {
+	return &QueueIterator[T]{queue: q, node: q.frontNode, idx: 0}
+}
+
+// End возвращает недействительный итератор, представляющий позицию "за последним элементом".
+func (q *Queue[T]) End() *QueueIterator[T] {
+	return &QueueIterator[T]{queue: q, node: nil, idx: q.size}
+}
+
+func (it *QueueIterator[T]) Valid() bool { return it.node != nil }
+
+func (it *QueueIterator[T]) Value() *T {
+	if it.node == nil {
+		return nil
+	}
+	return &it.node.data
+}
+
+func (it *QueueIterator[T]) Next() {
+	if it.node == nil {
+		return
+	}
+	it.node = it.node.next
+	it.idx++
+}
+
+func (it *QueueIterator[T]) Index() int { return it.idx }
+
+// Reset возвращает итератор к первому элементу очереди.
+func (it *QueueIterator[T]) Reset() {
+	it.node = it.queue.frontNode
+	it.idx = 0
+}
+
+// --- StackIterator ---
+
+// StackIterator — однонаправленный итератор Stack (от Top к Bottom). Хранит
+// ссылку на стек, чтобы Reset() мог вернуться к вершине без повторного Begin().
+type StackIterator[T any] struct {
+	stack *Stack[T]
+	node  *sNode[T]
+	idx   int
+}
+
+// Begin возвращает итератор, указывающий на вершину стека.
+func (s *Stack[T]) Begin() *StackIterator[T] {
+	return &StackIterator[T]{stack: s, node: s.topNode, idx: 0}
+}
+
+// End возвращает недействительный итератор, представляющий позицию "за дном стека".
+func (s *Stack[T]) End() *StackIterator[T] {
+	return &StackIterator[T]{stack: s, node: nil, idx: s.size}
+}
+
+func (it *StackIterator[T]) Valid() bool { return it.node != nil }
+
+func (it *StackIterator[T]) Value() *T {
+	if it.node == nil {
+		return nil
+	}
+	return &it.node.data
+}
+
+func (it *StackIterator[T]) Next() {
+	if it.node == nil {
+		return
+	}
+	it.node = it.node.next
+	it.idx++
+}
+
+func (it *StackIterator[T]) Index() int { return it.idx }
+
+// Reset возвращает итератор к вершине стека.
+func (it *StackIterator[T]) Reset() {
+	it.node = it.stack.topNode
+	it.idx = 0
+}
+
+// --- HashTableIterator ---
+
+// HashEntry — пара ключ-значение, возвращаемая HashTableIterator.
+type HashEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// HashTableIterator — однонаправленный итератор HashTable, обходящий бакеты
+// по порядку; порядок элементов внутри и между бакетами не гарантируется.
+type HashTableIterator[K comparable, V any] struct {
+	ht        *HashTable[K, V]
+	bucketIdx int
+	node      *entry[K, V]
+	idx       int
+	current   HashEntry[K, V]
+}
+
+// seekOccupied перемещает итератор на следующий занятый бакет, если текущий узел исчерпан.
+func (it *HashTableIterator[K, V]) seekOccupied() {
+	for it.node == nil && it.bucketIdx < it.ht.bucketCount-1 {
+		it.bucketIdx++
+		it.node = it.ht.buckets[it.bucketIdx]
+	}
+	if it.node != nil {
+		it.current = HashEntry[K, V]{Key: it.node.Key, Value: it.node.Value}
+	}
+}
+
+// Begin возвращает итератор, указывающий на первую пару ключ-значение таблицы.
+func (ht *HashTable[K, V]) Begin() *HashTableIterator[K, V] {
+	it := &HashTableIterator[K, V]{ht: ht, bucketIdx: 0, idx: 0}
+	if ht.bucketCount > 0 {
+		it.node = ht.buckets[0]
+	}
+	it.seekOccupied()
+	return it
+}
+
+// End возвращает недействительный итератор, представляющий позицию "за последним элементом".
+func (ht *HashTable[K, V]) End() *HashTableIterator[K, V] {
+	return &HashTableIterator[K, V]{ht: ht, bucketIdx: ht.bucketCount, node: nil, idx: ht.size}
+}
+
+func (it *HashTableIterator[K, V]) Valid() bool { return it.node != nil }
+
+func (it *HashTableIterator[K, V]) Value() *HashEntry[K, V] {
+	if it.node == nil {
+		return nil
+	}
+	return &it.current
+}
+
+func (it *HashTableIterator[K, V]) Next() {
+	if it.node == nil {
+		return
+	}
+	it.node = it.node.Next
+	it.idx++
+	it.seekOccupied()
+}
+
+func (it *HashTableIterator[K, V]) Index() int { return it.idx }
+
+// Reset возвращает итератор к первой паре ключ-значение таблицы.
+func (it *HashTableIterator[K, V]) Reset() {
+	it.bucketIdx = 0
+	it.node = nil
+	it.idx = 0
+	if it.ht.bucketCount > 0 {
+		it.node = it.ht.buckets[0]
+	}
+	it.seekOccupied()
+}
+
+// --- FullBinaryTreeIterator ---
+
+// FullBinaryTreeIterator — двунаправленный индексированный итератор
+// FullBinaryTree поверх снимка in-order обхода, сделанного в момент Begin/End.
+// Последующие изменения дерева не отражаются в уже созданном итераторе.
+type FullBinaryTreeIterator[T any] struct {
+	values []T
+	idx    int
+}
+
+func inorderCollect[T any](n *node[T], out *[]T) {
+	if n == nil {
+		return
+	}
+	inorderCollect(n.Left, out)
+	*out = append(*out, n.Data)
+	inorderCollect(n.Right, out)
+}
+
+// Begin возвращает итератор, указывающий на первый элемент in-order обхода.
+func (t *FullBinaryTree[T]) Begin() *FullBinaryTreeIterator[T] {
+	var values []T
+	inorderCollect(t.root, &values)
+	return &FullBinaryTreeIterator[T]{values: values, idx: 0}
+}
+
+// End возвращает недействительный итератор, представляющий позицию "за последним элементом".
+func (t *FullBinaryTree[T]) End() *FullBinaryTreeIterator[T] {
+	var values []T
+	inorderCollect(t.root, &values)
+	return &FullBinaryTreeIterator[T]{values: values, idx: len(values)}
+}
+
+func (it *FullBinaryTreeIterator[T]) Valid() bool {
+	return it.idx >= 0 && it.idx < len(it.values)
+}
+
+func (it *FullBinaryTreeIterator[T]) Value() *T {
+	if !it.Valid() {
+		return nil
+	}
+	return &it.values[it.idx]
+}
+
+func (it *FullBinaryTreeIterator[T]) Next()     { it.idx++ }
+func (it *FullBinaryTreeIterator[T]) Prev()     { it.idx-- }
+func (it *FullBinaryTreeIterator[T]) Index() int { return it.idx }
+func (it *FullBinaryTreeIterator[T]) Seek(i int) { it.idx = i }
+
+// Reset возвращает итератор к первому элементу снимка in-order обхода.
+func (it *FullBinaryTreeIterator[T]) Reset() { it.idx = 0 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/gob"
 	"errors"
 	"fmt"
@@ -216,8 +217,11 @@ func (l *ForwardList[T]) Print() {
 	fmt.Println("]")
 }
 
+// SerializeBinary кодирует gob во временный буфер из bufferpool, а не
+// напрямую в out, чтобы повторные сериализации не аллоцировали буфер заново.
 func (l *ForwardList[T]) SerializeBinary(out io.Writer) error {
-	enc := gob.NewEncoder(out)
+	buf := bytes.NewBuffer(bufferPoolGet(l.size * 16))
+	enc := gob.NewEncoder(buf)
 	if err := enc.Encode(l.size); err != nil {
 		return err
 	}
@@ -228,6 +232,10 @@ func (l *ForwardList[T]) SerializeBinary(out io.Writer) error {
 		}
 		current = current.Next
 	}
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	bufferPoolPut(buf.Bytes())
 	return nil
 }
 
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"encoding/binary"
 	"encoding/gob"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -20,8 +24,21 @@ type node[T any] struct {
 // FullBinaryTree реализует полное бинарное дерево.
 // T должен быть comparable для поддержки поиска и удаления.
 type FullBinaryTree[T comparable] struct {
-	root *node[T]
-	size int
+	root   *node[T]
+	size   int
+	parser Parser[T] // опционален, см. SetParser и DeserializeText
+}
+
+// Parser разбирает строковый токен в значение T. Используется DeserializeText
+// для типов, для которых разбора через fmt.Sscan недостаточно (например,
+// T, не реализующий fmt.Scanner, или требующий нестандартного формата).
+type Parser[T any] func(token string) (T, error)
+
+// SetParser регистрирует parser, используемый DeserializeText для разбора
+// токенов значений. Если parser не задан, DeserializeText разбирает токены
+// через fmt.Sscan, что работает для встроенных типов (int, string и т.д.).
+func (t *FullBinaryTree[T]) SetParser(parser Parser[T]) {
+	t.parser = parser
 }
 
 // NewFullBinaryTree создает новое пустое дерево.
@@ -103,29 +120,24 @@ func (t *FullBinaryTree[T]) Insert(value T) {
 
 // Find ищет значение в дереве.
 func (t *FullBinaryTree[T]) Find(value T) bool {
-	if t.root == nil {
-		return false
-	}
-
-	queue := []*node[T]{t.root}
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		if current.Data == value {
-			return true
-		}
-
-		if current.Left != nil {
-			queue = append(queue, current.Left)
-		}
-		if current.Right != nil {
-			queue = append(queue, current.Right)
-		}
-	}
-	return false
+	found := false
+	t.TreeWalk(context.Background(), TreeWalkHandler[T]{
+		Node: func(path TreePath[T]) error {
+			if path.Node().Data == value {
+				found = true
+				return errStopWalk
+			}
+			return nil
+		},
+	})
+	return found
 }
 
+// errStopWalk — внутренний сигнал "нашли, что искали", которым Find
+// останавливает TreeWalk досрочно. Не io/fs.SkipDir: здесь нужно прервать
+// весь обход, а не отсечь текущее поддерево.
+var errStopWalk = errors.New("stop tree walk")
+
 // Remove удаляет значение из дерева.
 // Использует стратегию замены на самый правый лист и удаление пары листьев.
 func (t *FullBinaryTree[T]) Remove(value T) {
@@ -221,20 +233,17 @@ func (t *FullBinaryTree[T]) Remove(value T) {
 	}
 }
 
-// IsFullBinaryTree проверяет корректность структуры.
+// IsFullBinaryTree проверяет корректность структуры — тонкая обертка над
+// TreeWalk, которая уже сообщает о нарушении инварианта через BadNode.
 func (t *FullBinaryTree[T]) IsFullBinaryTree() bool {
-	return isFullBinaryTreeHelper(t.root)
-}
-
-func isFullBinaryTreeHelper[T any](n *node[T]) bool {
-	if n == nil {
-		return true
-	}
-	// XOR: (Left==nil) != (Right==nil) означает, что есть только один ребенок
-	if (n.Left == nil) != (n.Right == nil) {
-		return false
-	}
-	return isFullBinaryTreeHelper(n.Left) && isFullBinaryTreeHelper(n.Right)
+	valid := true
+	t.TreeWalk(context.Background(), TreeWalkHandler[T]{
+		BadNode: func(path TreePath[T], err error) error {
+			valid = false
+			return err
+		},
+	})
+	return valid
 }
 
 // Print выводит содержимое (BFS).
@@ -280,8 +289,11 @@ func printInOrderHelper[T any](n *node[T]) {
 // ==========================================
 
 // Serialize сохраняет дерево в бинарном формате (используя gob).
+// Кодирование идет во временный буфер из bufferpool, а не напрямую в out,
+// чтобы повторные сериализации не аллоцировали буфер заново.
 func (t *FullBinaryTree[T]) Serialize(out io.Writer) error {
-	enc := gob.NewEncoder(out)
+	buf := bytes.NewBuffer(bufferPoolGet(t.size * 16))
+	enc := gob.NewEncoder(buf)
 	// Сохраняем размер для быстрой проверки, хотя gob восстановит структуру и так
 	if err := enc.Encode(t.size); err != nil {
 		return err
@@ -292,6 +304,10 @@ func (t *FullBinaryTree[T]) Serialize(out io.Writer) error {
 	if err := enc.Encode(t.root); err != nil {
 		return err
 	}
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	bufferPoolPut(buf.Bytes())
 	return nil
 }
 
@@ -323,7 +339,16 @@ func (t *FullBinaryTree[T]) Deserialize(in io.Reader) error {
 // Сериализация (Text)
 // ==========================================
 
-// SerializeText сохраняет дерево в текстовом формате.
+// treeNullToken — явный сентинел отсутствующего ребенка в preorder-обходе
+// SerializeText/DeserializeText. В отличие от прежнего "null", односимвольный
+// токен почти никогда не совпадет с текстовым представлением настоящего
+// значения T, что делает обход однозначно восстановимым.
+const treeNullToken = "#"
+
+// SerializeText сохраняет дерево в текстовом виде как preorder-обход с явным
+// сентинелом treeNullToken на месте отсутствующих детей — этого достаточно,
+// чтобы DeserializeText восстановил дерево любой формы, а не только то, где
+// форма выводима из самих значений (как в BST).
 func (t *FullBinaryTree[T]) SerializeText(out io.Writer) error {
 	if _, err := fmt.Fprintln(out, t.size); err != nil {
 		return err
@@ -333,7 +358,7 @@ func (t *FullBinaryTree[T]) SerializeText(out io.Writer) error {
 
 func serializeTextHelper[T any](n *node[T], out io.Writer) error {
 	if n == nil {
-		_, err := fmt.Fprint(out, "null ")
+		_, err := fmt.Fprint(out, treeNullToken+" ")
 		return err
 	}
 	if _, err := fmt.Fprintf(out, "%v ", n.Data); err != nil {
@@ -348,13 +373,15 @@ func serializeTextHelper[T any](n *node[T], out io.Writer) error {
 	return nil
 }
 
-// DeserializeText восстанавливает дерево из текстового формата.
+// DeserializeText восстанавливает дерево из формата SerializeText, разбирая
+// токены значений через t.parser (см. SetParser), либо, если он не
+// зарегистрирован, через fmt.Sscan. Размер дерева пересчитывается из
+// восстановленной структуры, а не берется из заголовка потока.
 func (t *FullBinaryTree[T]) DeserializeText(in io.Reader) error {
 	t.Clear()
 
-	// Читаем все содержимое для парсинга токенов
+	// Читаем все содержимое для парсинга токенов.
 	// В Go нет прямого аналога `in >> token`, поэтому читаем всё и разбиваем.
-	// Для больших данных это неэффективно, но соответствует логике C++ потока.
 	buf := new(bytes.Buffer)
 	if _, err := buf.ReadFrom(in); err != nil {
 		return err
@@ -365,50 +392,239 @@ func (t *FullBinaryTree[T]) DeserializeText(in io.Reader) error {
 		return errors.New("empty input")
 	}
 
-	// Парсинг размера
-	var newSize int
-	_, err := fmt.Sscanf(tokens[0], "%d", &newSize)
+	// Заголовок (размер) пропускается при разборе — используется только
+	// для обратной совместимости формата, фактический размер пересчитывается ниже.
+	tokenIndex := 1
+
+	parse := t.parser
+	if parse == nil {
+		parse = defaultTreeParser[T]
+	}
+
+	root, _, err := deserializeTextHelper(tokens, tokenIndex, parse)
 	if err != nil {
-		return fmt.Errorf("failed to parse size: %w", err)
+		return err
 	}
-	t.size = newSize
+	t.root = root
+	t.size = countTreeNodes(t.root)
+	return nil
+}
 
-	// Рекурсивный парсинг дерева
-	tokenIndex := 1
-	var parseErr error
-	t.root, tokenIndex, parseErr = deserializeTextHelper[T](tokens, tokenIndex)
-	return parseErr
+// defaultTreeParser — парсер по умолчанию для DeserializeText, когда
+// SetParser не вызывался. Подходит для встроенных типов (int, string и т.д.),
+// для которых fmt.Sscan разбирает одно значение из одного токена.
+func defaultTreeParser[T any](token string) (T, error) {
+	var value T
+	n, err := fmt.Sscan(token, &value)
+	if err != nil || n == 0 {
+		return value, fmt.Errorf("failed to parse value %q: %w", token, err)
+	}
+	return value, nil
 }
 
-func deserializeTextHelper[T comparable](tokens []string, index int) (*node[T], int, error) {
+func deserializeTextHelper[T any](tokens []string, index int, parse Parser[T]) (*node[T], int, error) {
 	if index >= len(tokens) {
-		return nil, index, nil // Или ошибка, если ожидались данные
+		return nil, index, errors.New("unexpected end of input")
 	}
 
 	token := tokens[index]
 	index++
 
-	if token == "null" {
+	if token == treeNullToken {
 		return nil, index, nil
 	}
 
-	var value T
-	// Sscanf требует указатель на конкретный тип.
-	// Для Generic T это немного сложнее через fmt.
-	// Используем временный интерфейс или reflection, но fmt.Sscan работает с any.
-	n, err := fmt.Sscan(token, &value)
-	if err != nil || n == 0 {
-		return nil, index, fmt.Errorf("failed to parse value '%s': %v", token, err)
+	value, err := parse(token)
+	if err != nil {
+		return nil, index, err
 	}
 
 	newNode := &node[T]{Data: value}
-	
+
 	var errL, errR error
-	newNode.Left, index, errL = deserializeTextHelper[T](tokens, index)
-	if errL != nil { return nil, index, errL }
-	
-	newNode.Right, index, errR = deserializeTextHelper[T](tokens, index)
-	if errR != nil { return nil, index, errR }
+	newNode.Left, index, errL = deserializeTextHelper(tokens, index, parse)
+	if errL != nil {
+		return nil, index, errL
+	}
+
+	newNode.Right, index, errR = deserializeTextHelper(tokens, index, parse)
+	if errR != nil {
+		return nil, index, errR
+	}
 
 	return newNode, index, nil
+}
+
+// ==========================================
+// Сериализация (компактный бинарный формат)
+// ==========================================
+
+// SerializeCompact сохраняет дерево в компактном бинарном preorder-формате:
+// для каждого узла пишется один флаговый байт (бит 0 — есть левый ребенок,
+// бит 1 — есть правый), затем gob-закодированное значение Data, которому
+// предшествует его длина как varint. В отличие от Serialize (gob всего
+// дерева целиком), этот формат не зависит от способности gob кодировать
+// рекурсивные указатели и допускает потоковое чтение/запись узел за узлом.
+func (t *FullBinaryTree[T]) SerializeCompact(out io.Writer) error {
+	hasRoot := byte(0)
+	if t.root != nil {
+		hasRoot = 1
+	}
+	if _, err := out.Write([]byte{hasRoot}); err != nil {
+		return err
+	}
+	if t.root == nil {
+		return nil
+	}
+	return serializeCompactHelper(t.root, out)
+}
+
+func serializeCompactHelper[T any](n *node[T], out io.Writer) error {
+	var flags byte
+	if n.Left != nil {
+		flags |= 1 << 0
+	}
+	if n.Right != nil {
+		flags |= 1 << 1
+	}
+
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(n.Data); err != nil {
+		return err
+	}
+
+	varintBuf := make([]byte, binary.MaxVarintLen64)
+	ln := binary.PutUvarint(varintBuf, uint64(payload.Len()))
+
+	if _, err := out.Write([]byte{flags}); err != nil {
+		return err
+	}
+	if _, err := out.Write(varintBuf[:ln]); err != nil {
+		return err
+	}
+	if _, err := out.Write(payload.Bytes()); err != nil {
+		return err
+	}
+
+	if n.Left != nil {
+		if err := serializeCompactHelper(n.Left, out); err != nil {
+			return err
+		}
+	}
+	if n.Right != nil {
+		if err := serializeCompactHelper(n.Right, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeserializeCompact восстанавливает дерево из формата SerializeCompact.
+func (t *FullBinaryTree[T]) DeserializeCompact(in io.Reader) error {
+	t.Clear()
+
+	br := bufio.NewReader(in)
+	hasRoot, err := br.ReadByte()
+	if err != nil {
+		return err
+	}
+	if hasRoot == 0 {
+		return nil
+	}
+
+	root, err := deserializeCompactHelper[T](br)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	t.size = countTreeNodes(t.root)
+	return nil
+}
+
+func deserializeCompactHelper[T any](br *bufio.Reader) (*node[T], error) {
+	flags, err := br.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	payloadLen, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	payload := make([]byte, payloadLen)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		return nil, err
+	}
+
+	var data T
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&data); err != nil {
+		return nil, err
+	}
+	n := &node[T]{Data: data}
+
+	if flags&(1<<0) != 0 {
+		n.Left, err = deserializeCompactHelper[T](br)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if flags&(1<<1) != 0 {
+		n.Right, err = deserializeCompactHelper[T](br)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// ==========================================
+// Сериализация (JSON)
+// ==========================================
+
+// treeJSON — вспомогательное дерево-зеркало для MarshalJSON/UnmarshalJSON:
+// экспортирует поля с json-тегами, которых нет у node (чтобы не завязывать
+// JSON-формат на внутреннее представление узла).
+type treeJSON[T any] struct {
+	Data  T            `json:"data"`
+	Left  *treeJSON[T] `json:"left,omitempty"`
+	Right *treeJSON[T] `json:"right,omitempty"`
+}
+
+func nodeToTreeJSON[T any](n *node[T]) *treeJSON[T] {
+	if n == nil {
+		return nil
+	}
+	return &treeJSON[T]{
+		Data:  n.Data,
+		Left:  nodeToTreeJSON(n.Left),
+		Right: nodeToTreeJSON(n.Right),
+	}
+}
+
+func treeJSONToNode[T any](j *treeJSON[T]) *node[T] {
+	if j == nil {
+		return nil
+	}
+	return &node[T]{
+		Data:  j.Data,
+		Left:  treeJSONToNode(j.Left),
+		Right: treeJSONToNode(j.Right),
+	}
+}
+
+// MarshalJSON реализует json.Marshaler: дерево кодируется как вложенная
+// структура {"data":..., "left":..., "right":...}, null для пустого дерева.
+func (t *FullBinaryTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeToTreeJSON(t.root))
+}
+
+// UnmarshalJSON реализует json.Unmarshaler, обратный к MarshalJSON.
+func (t *FullBinaryTree[T]) UnmarshalJSON(data []byte) error {
+	var j *treeJSON[T]
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	t.root = treeJSONToNode(j)
+	t.size = countTreeNodes(t.root)
+	return nil
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/gob"
 	"fmt"
 	"hash/fnv"
@@ -277,20 +278,29 @@ func (ht *HashTable[K, V]) Deserialize(in io.Reader) error {
 
 // SerializeText сохраняет таблицу в текстовом виде.
 // Формат: <size> <bucket_count>\n<key> <value>\n...
+// Текст собирается во временном буфере из bufferpool и пишется в out одним
+// вызовом Write, чтобы повторные сериализации не аллоцировали буфер заново.
 func (ht *HashTable[K, V]) SerializeText(out io.Writer) error {
-	if _, err := fmt.Fprintf(out, "%d %d\n", ht.size, ht.bucketCount); err != nil {
+	buf := bytes.NewBuffer(bufferPoolGet(ht.size * 32))
+
+	if _, err := fmt.Fprintf(buf, "%d %d\n", ht.size, ht.bucketCount); err != nil {
 		return err
 	}
 
 	for _, head := range ht.buckets {
 		current := head
 		for current != nil {
-			if _, err := fmt.Fprintf(out, "%v %v\n", current.Key, current.Value); err != nil {
+			if _, err := fmt.Fprintf(buf, "%v %v\n", current.Key, current.Value); err != nil {
 				return err
 			}
 			current = current.Next
 		}
 	}
+
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	bufferPoolPut(buf.Bytes())
 	return nil
 }
 
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"io"
+)
+
+// htoSlot — один слот плоского backing-массива HashTableOpen. occupied
+// отличает пустой слот от слота с нулевым значением; dist — расстояние
+// пробирования (сколько шагов слот сместился от своего идеального бакета),
+// используемое правилом Robin Hood "богатый отдает бедному".
+type htoSlot[K comparable, V any] struct {
+	hash     uint64
+	key      K
+	value    V
+	dist     uint8
+	occupied bool
+}
+
+// HashTableOpen — альтернатива HashTable на основе открытой адресации
+// (Robin Hood hashing с backward-shift удалением) вместо цепочек. Публичный
+// API совпадает с HashTable (Insert/Get/Remove/Find/LoadFactor и т.д.), но
+// все пары хранятся в одном плоском слайсе слотов без per-entry аллокаций.
+type HashTableOpen[K comparable, V any] struct {
+	slots       []htoSlot[K, V]
+	size        int
+	bucketCount int
+}
+
+// NewHashTableOpen создает новую хеш-таблицу с открытой адресацией.
+// initialBucketCount опционален (по умолчанию 16).
+func NewHashTableOpen[K comparable, V any](initialBucketCount ...int) *HashTableOpen[K, V] {
+	bc := 16
+	if len(initialBucketCount) > 0 && initialBucketCount[0] > 0 {
+		bc = initialBucketCount[0]
+	}
+	return &HashTableOpen[K, V]{
+		slots:       make([]htoSlot[K, V], bc),
+		size:        0,
+		bucketCount: bc,
+	}
+}
+
+// htoHash вычисляет 64-битный хеш ключа (FNV-1a от строкового представления,
+// как и в HashTable.hash).
+func htoHash[K comparable](key K) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(fmt.Sprintf("%v", key)))
+	return h.Sum64()
+}
+
+// rehash увеличивает емкость в 2 раза и заново вставляет все занятые слоты.
+func (ht *HashTableOpen[K, V]) rehash() {
+	old := ht.slots
+	ht.bucketCount *= 2
+	ht.slots = make([]htoSlot[K, V], ht.bucketCount)
+	ht.size = 0
+
+	for _, s := range old {
+		if s.occupied {
+			ht.insertRaw(s.hash, s.key, s.value)
+		}
+	}
+}
+
+// insertRaw выполняет саму вставку методом Robin Hood без проверки LoadFactor
+// (используется как внутренними Insert, так и rehash).
+func (ht *HashTableOpen[K, V]) insertRaw(hash uint64, key K, value V) {
+	idx := int(hash % uint64(ht.bucketCount))
+	incoming := htoSlot[K, V]{hash: hash, key: key, value: value, dist: 0, occupied: true}
+
+	for {
+		current := &ht.slots[idx]
+		if !current.occupied {
+			*current = incoming
+			ht.size++
+			return
+		}
+		if current.hash == incoming.hash && current.key == incoming.key {
+			current.value = incoming.value
+			return
+		}
+		// "Богатый отдает бедному": если текущий слот ближе к своему идеальному
+		// бакету, чем новичок, меняем их местами и продолжаем пробирование с
+		// вытесненным элементом.
+		if current.dist < incoming.dist {
+			incoming, *current = *current, incoming
+		}
+		idx = (idx + 1) % ht.bucketCount
+		incoming.dist++
+	}
+}
+
+// Insert вставляет или обновляет пару ключ-значение.
+func (ht *HashTableOpen[K, V]) Insert(key K, value V) {
+	if ht.LoadFactor() > 0.85 {
+		ht.rehash()
+	}
+	ht.insertRaw(htoHash(key), key, value)
+}
+
+// find возвращает индекс слота с ключом key, либо -1, если ключ не найден.
+func (ht *HashTableOpen[K, V]) find(key K) int {
+	hash := htoHash(key)
+	idx := int(hash % uint64(ht.bucketCount))
+	var dist uint8 = 0
+
+	for {
+		slot := &ht.slots[idx]
+		if !slot.occupied || dist > slot.dist {
+			return -1
+		}
+		if slot.hash == hash && slot.key == key {
+			return idx
+		}
+		idx = (idx + 1) % ht.bucketCount
+		dist++
+	}
+}
+
+// Get получает значение по ключу.
+func (ht *HashTableOpen[K, V]) Get(key K) (V, error) {
+	if idx := ht.find(key); idx >= 0 {
+		return ht.slots[idx].value, nil
+	}
+	var zero V
+	return zero, fmt.Errorf("key not found")
+}
+
+// Find проверяет наличие ключа в таблице.
+func (ht *HashTableOpen[K, V]) Find(key K) bool {
+	return ht.find(key) >= 0
+}
+
+// Remove удаляет элемент по ключу с помощью backward-shift: последующие
+// элементы сдвигаются на один слот назад (с уменьшением dist), пока не
+// встретится пустой слот или слот с dist==0 (то есть стоящий на своем
+// идеальном месте — сдвигать его дальше некуда).
+func (ht *HashTableOpen[K, V]) Remove(key K) error {
+	idx := ht.find(key)
+	if idx < 0 {
+		return fmt.Errorf("key not found")
+	}
+
+	next := (idx + 1) % ht.bucketCount
+	for ht.slots[next].occupied && ht.slots[next].dist > 0 {
+		ht.slots[idx] = ht.slots[next]
+		ht.slots[idx].dist--
+		idx = next
+		next = (next + 1) % ht.bucketCount
+	}
+	ht.slots[idx] = htoSlot[K, V]{}
+	ht.size--
+	return nil
+}
+
+// GetSize возвращает текущее количество элементов.
+func (ht *HashTableOpen[K, V]) GetSize() int {
+	return ht.size
+}
+
+// GetBucketCount возвращает текущее количество бакетов.
+func (ht *HashTableOpen[K, V]) GetBucketCount() int {
+	return ht.bucketCount
+}
+
+// IsEmpty проверяет, пуста ли таблица.
+func (ht *HashTableOpen[K, V]) IsEmpty() bool {
+	return ht.size == 0
+}
+
+// Clear полностью очищает таблицу.
+func (ht *HashTableOpen[K, V]) Clear() {
+	ht.slots = make([]htoSlot[K, V], ht.bucketCount)
+	ht.size = 0
+}
+
+// LoadFactor вычисляет текущий коэффициент заполнения.
+func (ht *HashTableOpen[K, V]) LoadFactor() float64 {
+	return float64(ht.size) / float64(ht.bucketCount)
+}
+
+// Print выводит содержимое занятых слотов в консоль.
+func (ht *HashTableOpen[K, V]) Print() {
+	fmt.Printf("HashTableOpen (size: %d, buckets: %d):\n", ht.size, ht.bucketCount)
+	for i, s := range ht.slots {
+		if s.occupied {
+			fmt.Printf("  Slot %d (dist %d): [%v:%v]\n", i, s.dist, s.key, s.value)
+		}
+	}
+}
+
+// --- Сериализация ---
+
+// htoEntryData используется для gob сериализации плоского списка данных.
+type htoEntryData[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Serialize сохраняет таблицу в бинарном формате (используя gob).
+func (ht *HashTableOpen[K, V]) Serialize(out io.Writer) error {
+	enc := gob.NewEncoder(out)
+
+	if err := enc.Encode(ht.bucketCount); err != nil {
+		return err
+	}
+	if err := enc.Encode(ht.size); err != nil {
+		return err
+	}
+
+	for _, s := range ht.slots {
+		if s.occupied {
+			data := htoEntryData[K, V]{Key: s.key, Value: s.value}
+			if err := enc.Encode(data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Deserialize восстанавливает таблицу из бинарного формата.
+func (ht *HashTableOpen[K, V]) Deserialize(in io.Reader) error {
+	ht.Clear()
+
+	dec := gob.NewDecoder(in)
+
+	var newBucketCount int
+	if err := dec.Decode(&newBucketCount); err != nil {
+		return err
+	}
+	var newSize int
+	if err := dec.Decode(&newSize); err != nil {
+		return err
+	}
+
+	ht.bucketCount = newBucketCount
+	ht.slots = make([]htoSlot[K, V], ht.bucketCount)
+
+	for i := 0; i < newSize; i++ {
+		var data htoEntryData[K, V]
+		if err := dec.Decode(&data); err != nil {
+			return err
+		}
+		ht.Insert(data.Key, data.Value)
+	}
+
+	return nil
+}
+
+// SerializeText сохраняет таблицу в текстовом виде.
+// Формат: <size> <bucket_count>\n<key> <value>\n...
+func (ht *HashTableOpen[K, V]) SerializeText(out io.Writer) error {
+	if _, err := fmt.Fprintf(out, "%d %d\n", ht.size, ht.bucketCount); err != nil {
+		return err
+	}
+
+	for _, s := range ht.slots {
+		if s.occupied {
+			if _, err := fmt.Fprintf(out, "%v %v\n", s.key, s.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DeserializeText восстанавливает таблицу из текстового формата.
+func (ht *HashTableOpen[K, V]) DeserializeText(in io.Reader) error {
+	ht.Clear()
+
+	var newSize, newBucketCount int
+	if _, err := fmt.Fscan(in, &newSize, &newBucketCount); err != nil {
+		return err
+	}
+
+	ht.bucketCount = newBucketCount
+	ht.slots = make([]htoSlot[K, V], ht.bucketCount)
+
+	for i := 0; i < newSize; i++ {
+		var key K
+		var value V
+		if _, err := fmt.Fscan(in, &key, &value); err != nil {
+			return err
+		}
+		ht.Insert(key, value)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"errors"
+	"iter"
+)
+
+// ErrInvalidIterator возвращается операциями итератора, для которых
+// требуется указывать на существующий элемент (Remove, InsertAfter),
+// когда итератор уже недействителен (достиг End()).
+var ErrInvalidIterator = errors.New("iterator is invalid")
+
+// ForwardIterator представляет позицию обхода ForwardList.
+// Так как список односвязный, итератор хранит указатель на предыдущий узел,
+// чтобы Remove/InsertBefore могли перелинковать список за O(1) без повторного
+// поиска предшественника.
+type ForwardIterator[T comparable] struct {
+	list    *ForwardList[T]
+	prev    *forwardNode[T]
+	current *forwardNode[T]
+	idx     int
+}
+
+// Begin возвращает итератор, указывающий на первый элемент списка.
+func (l *ForwardList[T]) Begin() *ForwardIterator[T] {
+	return &ForwardIterator[T]{list: l, current: l.head, idx: 0}
+}
+
+// End возвращает недействительный итератор, представляющий позицию "за последним элементом".
+func (l *ForwardList[T]) End() *ForwardIterator[T] {
+	var prev *forwardNode[T]
+	current := l.head
+	for current != nil {
+		prev = current
+		current = current.Next
+	}
+	return &ForwardIterator[T]{list: l, prev: prev, current: nil, idx: l.size}
+}
+
+// Index возвращает позицию итератора относительно начала списка (для Iterator).
+func (it *ForwardIterator[T]) Index() int {
+	return it.idx
+}
+
+// Valid сообщает, указывает ли итератор на существующий элемент.
+func (it *ForwardIterator[T]) Valid() bool {
+	return it.current != nil
+}
+
+// Value возвращает указатель на значение текущего элемента, либо nil для недействительного итератора.
+func (it *ForwardIterator[T]) Value() *T {
+	if it.current == nil {
+		return nil
+	}
+	return &it.current.Data
+}
+
+// Next сдвигает итератор на следующий элемент.
+func (it *ForwardIterator[T]) Next() {
+	if it.current == nil {
+		return
+	}
+	it.prev = it.current
+	it.current = it.current.Next
+	it.idx++
+}
+
+// Reset возвращает итератор к первому элементу списка.
+func (it *ForwardIterator[T]) Reset() {
+	it.prev = nil
+	it.current = it.list.head
+	it.idx = 0
+}
+
+// Remove удаляет элемент, на который указывает итератор, и сдвигает итератор
+// на следующий за ним элемент. Сложность: O(1).
+func (it *ForwardIterator[T]) Remove() error {
+	if it.current == nil {
+		return ErrInvalidIterator
+	}
+	next := it.current.Next
+	if it.prev != nil {
+		it.prev.Next = next
+	} else {
+		it.list.head = next
+	}
+	it.list.size--
+	it.current = next
+	return nil
+}
+
+// InsertBefore вставляет значение перед текущим элементом (либо в конец списка,
+// если итератор указывает на End()). Сложность: O(1).
+func (it *ForwardIterator[T]) InsertBefore(value T) error {
+	if it.current == nil {
+		it.list.PushBack(value)
+		return nil
+	}
+	newNode := &forwardNode[T]{Data: value, Next: it.current}
+	if it.prev != nil {
+		it.prev.Next = newNode
+	} else {
+		it.list.head = newNode
+	}
+	it.list.size++
+	it.prev = newNode
+	it.idx++
+	return nil
+}
+
+// InsertAfter вставляет значение сразу после текущего элемента.
+// Требует действительного итератора. Сложность: O(1).
+func (it *ForwardIterator[T]) InsertAfter(value T) error {
+	if it.current == nil {
+		return ErrInvalidIterator
+	}
+	newNode := &forwardNode[T]{Data: value, Next: it.current.Next}
+	it.current.Next = newNode
+	it.list.size++
+	return nil
+}
+
+// Range проходит по всем элементам списка, вызывая fn с указателем на каждый.
+// Обход прекращается, как только fn вернет false.
+func (l *ForwardList[T]) Range(fn func(*T) bool) {
+	current := l.head
+	for current != nil {
+		if !fn(&current.Data) {
+			return
+		}
+		current = current.Next
+	}
+}
+
+// All возвращает iter.Seq2 для использования с `for i, v := range list.All()` (Go 1.23+).
+func (l *ForwardList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		current := l.head
+		for current != nil {
+			if !yield(i, current.Data) {
+				return
+			}
+			i++
+			current = current.Next
+		}
+	}
+}
+
+// DoubleIterator представляет позицию обхода DoubleList в любом направлении.
+type DoubleIterator[T comparable] struct {
+	list    *DoubleList[T]
+	current *doubleNode[T]
+	idx     int
+}
+
+// Begin возвращает итератор, указывающий на первый элемент списка.
+func (l *DoubleList[T]) Begin() *DoubleIterator[T] {
+	return &DoubleIterator[T]{list: l, current: l.head, idx: 0}
+}
+
+// End возвращает недействительный итератор, представляющий позицию "за последним элементом".
+func (l *DoubleList[T]) End() *DoubleIterator[T] {
+	return &DoubleIterator[T]{list: l, current: nil, idx: l.size}
+}
+
+// RBegin возвращает итератор, указывающий на последний элемент списка,
+// для обхода в обратном порядке с помощью Prev.
+func (l *DoubleList[T]) RBegin() *DoubleIterator[T] {
+	return &DoubleIterator[T]{list: l, current: l.tail, idx: l.size - 1}
+}
+
+// Index возвращает позицию итератора относительно начала списка (для Iterator).
+func (it *DoubleIterator[T]) Index() int {
+	return it.idx
+}
+
+// Valid сообщает, указывает ли итератор на существующий элемент.
+func (it *DoubleIterator[T]) Valid() bool {
+	return it.current != nil
+}
+
+// Value возвращает указатель на значение текущего элемента, либо nil для недействительного итератора.
+func (it *DoubleIterator[T]) Value() *T {
+	if it.current == nil {
+		return nil
+	}
+	return &it.current.Data
+}
+
+// Next сдвигает итератор к следующему элементу.
+func (it *DoubleIterator[T]) Next() {
+	if it.current == nil {
+		return
+	}
+	it.current = it.current.Next
+	it.idx++
+}
+
+// Prev сдвигает итератор к предыдущему элементу.
+func (it *DoubleIterator[T]) Prev() {
+	if it.current == nil {
+		return
+	}
+	it.current = it.current.Prev
+	it.idx--
+}
+
+// Reset возвращает итератор к первому элементу списка.
+func (it *DoubleIterator[T]) Reset() {
+	it.current = it.list.head
+	it.idx = 0
+}
+
+// Remove удаляет элемент, на который указывает итератор, и сдвигает итератор
+// на следующий за ним элемент. Сложность: O(1).
+func (it *DoubleIterator[T]) Remove() error {
+	if it.current == nil {
+		return ErrInvalidIterator
+	}
+	node := it.current
+	next := node.Next
+
+	if node.Prev != nil {
+		node.Prev.Next = node.Next
+	} else {
+		it.list.head = node.Next
+	}
+	if node.Next != nil {
+		node.Next.Prev = node.Prev
+	} else {
+		it.list.tail = node.Prev
+	}
+	it.list.size--
+	it.current = next
+	return nil
+}
+
+// InsertBefore вставляет значение перед текущим элементом (либо в конец списка,
+// если итератор указывает на End()). Сложность: O(1).
+func (it *DoubleIterator[T]) InsertBefore(value T) error {
+	if it.current == nil {
+		it.list.PushBack(value)
+		return nil
+	}
+	newNode := &doubleNode[T]{Data: value, Next: it.current, Prev: it.current.Prev}
+	if it.current.Prev != nil {
+		it.current.Prev.Next = newNode
+	} else {
+		it.list.head = newNode
+	}
+	it.current.Prev = newNode
+	it.list.size++
+	it.idx++
+	return nil
+}
+
+// InsertAfter вставляет значение сразу после текущего элемента.
+// Требует действительного итератора. Сложность: O(1).
+func (it *DoubleIterator[T]) InsertAfter(value T) error {
+	if it.current == nil {
+		return ErrInvalidIterator
+	}
+	newNode := &doubleNode[T]{Data: value, Prev: it.current, Next: it.current.Next}
+	if it.current.Next != nil {
+		it.current.Next.Prev = newNode
+	} else {
+		it.list.tail = newNode
+	}
+	it.current.Next = newNode
+	it.list.size++
+	return nil
+}
+
+// Range проходит по всем элементам списка, вызывая fn с указателем на каждый.
+// Обход прекращается, как только fn вернет false.
+func (l *DoubleList[T]) Range(fn func(*T) bool) {
+	current := l.head
+	for current != nil {
+		if !fn(&current.Data) {
+			return
+		}
+		current = current.Next
+	}
+}
+
+// All возвращает iter.Seq2 для использования с `for i, v := range list.All()` (Go 1.23+).
+func (l *DoubleList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		current := l.head
+		for current != nil {
+			if !yield(i, current.Data) {
+				return
+			}
+			i++
+			current = current.Next
+		}
+	}
+}
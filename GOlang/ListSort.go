@@ -0,0 +1,248 @@
+package main
+
+// Sort упорядочивает ForwardList на месте, перелинковывая существующие узлы
+// (новые узлы не выделяются). Использует top-down сортировку слиянием по
+// указателям Next, поэтому работает за O(N log N) и является стабильной.
+func (l *ForwardList[T]) Sort(less func(a, b T) bool) {
+	l.head = mergeSortForward(l.head, less)
+}
+
+// SortStable ведет себя идентично Sort: используемый алгоритм слияния уже
+// стабилен (при равенстве элементов первым берется узел из левой половины).
+func (l *ForwardList[T]) SortStable(less func(a, b T) bool) {
+	l.Sort(less)
+}
+
+// IsSorted проверяет, упорядочен ли список согласно less.
+// Сложность: O(N).
+func (l *ForwardList[T]) IsSorted(less func(a, b T) bool) bool {
+	current := l.head
+	for current != nil && current.Next != nil {
+		if less(current.Next.Data, current.Data) {
+			return false
+		}
+		current = current.Next
+	}
+	return true
+}
+
+// BubbleSortOptimized сортирует список пузырьком, переставляя данные узлов
+// (без перевыделения памяти). Реализует две стандартные оптимизации:
+// ранний выход, если за проход не было ни одной перестановки, и сужающуюся
+// границу, так как после i-го прохода последние i элементов уже на своих местах.
+func (l *ForwardList[T]) BubbleSortOptimized(less func(a, b T) bool) {
+	if l.head == nil {
+		return
+	}
+
+	// lastUnsorted указывает на узел, являющийся текущей границей
+	// отсортированного хвоста (nil значит "до конца списка").
+	var lastUnsorted *forwardNode[T]
+	for lastUnsorted != l.head.Next {
+		swapped := false
+		var newLastUnsorted *forwardNode[T]
+
+		current := l.head
+		for current.Next != lastUnsorted {
+			if less(current.Next.Data, current.Data) {
+				current.Data, current.Next.Data = current.Next.Data, current.Data
+				swapped = true
+			}
+			newLastUnsorted = current.Next
+			current = current.Next
+		}
+
+		lastUnsorted = newLastUnsorted
+		if !swapped {
+			break
+		}
+	}
+}
+
+// mergeSortForward сортирует односвязный список [head..] методом слияния,
+// разбивая его на половины медленным/быстрым указателем и перелинковывая узлы.
+func mergeSortForward[T any](head *forwardNode[T], less func(a, b T) bool) *forwardNode[T] {
+	if head == nil || head.Next == nil {
+		return head
+	}
+
+	left, right := splitForward(head)
+	left = mergeSortForward(left, less)
+	right = mergeSortForward(right, less)
+	return mergeForward(left, right, less)
+}
+
+// splitForward делит список на две примерно равные половины (slow/fast указатели)
+// и обрывает связь между ними.
+func splitForward[T any](head *forwardNode[T]) (*forwardNode[T], *forwardNode[T]) {
+	slow, fast := head, head.Next
+	for fast != nil && fast.Next != nil {
+		slow = slow.Next
+		fast = fast.Next.Next
+	}
+	right := slow.Next
+	slow.Next = nil
+	return head, right
+}
+
+// mergeForward сливает два отсортированных односвязных списка, перелинковывая
+// существующие узлы. При равенстве элементов первым берется узел из a (стабильность).
+func mergeForward[T any](a, b *forwardNode[T], less func(x, y T) bool) *forwardNode[T] {
+	dummy := &forwardNode[T]{}
+	tail := dummy
+
+	for a != nil && b != nil {
+		if less(b.Data, a.Data) {
+			tail.Next = b
+			b = b.Next
+		} else {
+			tail.Next = a
+			a = a.Next
+		}
+		tail = tail.Next
+	}
+	if a != nil {
+		tail.Next = a
+	} else {
+		tail.Next = b
+	}
+	return dummy.Next
+}
+
+// Sort упорядочивает DoubleList на месте, перелинковывая существующие узлы.
+// Использует восходящую (bottom-up) сортировку слиянием по Next, после чего
+// одним проходом восстанавливает Prev, head и tail. O(N log N) времени, O(1)
+// дополнительных выделений памяти.
+func (l *DoubleList[T]) Sort(less func(a, b T) bool) {
+	l.head = bottomUpMergeSort(l.head, l.size, less)
+	fixDoubleListLinks(l)
+}
+
+// SortStable ведет себя идентично Sort: слияние стабильно по построению.
+func (l *DoubleList[T]) SortStable(less func(a, b T) bool) {
+	l.Sort(less)
+}
+
+// IsSorted проверяет, упорядочен ли список согласно less.
+func (l *DoubleList[T]) IsSorted(less func(a, b T) bool) bool {
+	current := l.head
+	for current != nil && current.Next != nil {
+		if less(current.Next.Data, current.Data) {
+			return false
+		}
+		current = current.Next
+	}
+	return true
+}
+
+// BubbleSortOptimized сортирует список пузырьком, переставляя данные узлов.
+// Реализует ранний выход при отсутствии перестановок за проход и сужающуюся
+// границу необработанного хвоста.
+func (l *DoubleList[T]) BubbleSortOptimized(less func(a, b T) bool) {
+	if l.head == nil {
+		return
+	}
+
+	var lastUnsorted *doubleNode[T]
+	for lastUnsorted != l.head.Next {
+		swapped := false
+		var newLastUnsorted *doubleNode[T]
+
+		current := l.head
+		for current.Next != lastUnsorted {
+			if less(current.Next.Data, current.Data) {
+				current.Data, current.Next.Data = current.Next.Data, current.Data
+				swapped = true
+			}
+			newLastUnsorted = current.Next
+			current = current.Next
+		}
+
+		lastUnsorted = newLastUnsorted
+		if !swapped {
+			break
+		}
+	}
+}
+
+// bottomUpMergeSort сортирует цепочку узлов (связанных через Next) методом
+// восходящего слияния: сначала сливаются подсписки длины 1, затем 2, 4 и т.д.
+// Prev намеренно не поддерживается во время работы — его восстанавливает
+// fixDoubleListLinks после завершения сортировки.
+func bottomUpMergeSort[T any](head *doubleNode[T], size int, less func(a, b T) bool) *doubleNode[T] {
+	if head == nil || head.Next == nil {
+		return head
+	}
+
+	dummy := &doubleNode[T]{}
+	for width := 1; width < size; width *= 2 {
+		dummy.Next = nil
+		tail := dummy
+		current := head
+
+		for current != nil {
+			left := current
+			right := splitDoubleAfter(left, width)
+			current = splitDoubleAfter(right, width)
+
+			tail = mergeDouble(tail, left, right, less)
+		}
+		head = dummy.Next
+	}
+	return head
+}
+
+// splitDoubleAfter отсоединяет и возвращает хвост списка после первых n узлов,
+// начиная с head. Возвращает nil, если список короче n узлов.
+func splitDoubleAfter[T any](head *doubleNode[T], n int) *doubleNode[T] {
+	if head == nil {
+		return nil
+	}
+	current := head
+	for i := 1; i < n && current.Next != nil; i++ {
+		current = current.Next
+	}
+	rest := current.Next
+	current.Next = nil
+	return rest
+}
+
+// mergeDouble сливает два отсортированных подсписка (a, b) в конец tail,
+// используя только Next (Prev чинится отдельно). Возвращает новый хвост результата.
+func mergeDouble[T any](tail, a, b *doubleNode[T], less func(x, y T) bool) *doubleNode[T] {
+	for a != nil && b != nil {
+		if less(b.Data, a.Data) {
+			tail.Next = b
+			b = b.Next
+		} else {
+			tail.Next = a
+			a = a.Next
+		}
+		tail = tail.Next
+	}
+	if a != nil {
+		tail.Next = a
+		for tail.Next != nil {
+			tail = tail.Next
+		}
+	} else if b != nil {
+		tail.Next = b
+		for tail.Next != nil {
+			tail = tail.Next
+		}
+	}
+	return tail
+}
+
+// fixDoubleListLinks восстанавливает Prev, head и tail после сортировки,
+// которая перелинковывала узлы только через Next.
+func fixDoubleListLinks[T comparable](l *DoubleList[T]) {
+	var prev *doubleNode[T]
+	current := l.head
+	for current != nil {
+		current.Prev = prev
+		prev = current
+		current = current.Next
+	}
+	l.tail = prev
+}
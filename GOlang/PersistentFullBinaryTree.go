@@ -0,0 +1,401 @@
+package main
+
+import "fmt"
+
+// pnode представляет неизменяемый узел персистентного дерева.
+// Once created, a pnode's fields are never mutated again — every logical
+// modification builds new nodes along the affected path and reuses (shares)
+// every subtree that did not change, similar to a copy-on-write btree.
+type pnode[T any] struct {
+	Data  T
+	Left  *pnode[T]
+	Right *pnode[T]
+}
+
+// PersistentFullBinaryTree реализует персистентный (immutable) вариант
+// FullBinaryTree: Insert и Remove не изменяют существующее дерево, а
+// возвращают новую версию, разделяющую с предыдущей все неизменившиеся
+// поддеревья. Snapshot() поэтому стоит O(1), а хранение N версий стоит
+// O(N * log size), а не O(N * size).
+type PersistentFullBinaryTree[T comparable] struct {
+	root *pnode[T]
+	size int
+}
+
+// NewPersistentFullBinaryTree создает новое пустое персистентное дерево.
+func NewPersistentFullBinaryTree[T comparable]() *PersistentFullBinaryTree[T] {
+	return &PersistentFullBinaryTree[T]{}
+}
+
+// Snapshot возвращает независимую ссылку на текущую версию дерева.
+// Сложность: O(1), так как узлы неизменяемы и полностью разделяются между версиями.
+func (t *PersistentFullBinaryTree[T]) Snapshot() *PersistentFullBinaryTree[T] {
+	return &PersistentFullBinaryTree[T]{root: t.root, size: t.size}
+}
+
+// GetSize возвращает количество узлов в этой версии дерева.
+func (t *PersistentFullBinaryTree[T]) GetSize() int {
+	return t.size
+}
+
+// IsEmpty проверяет, пуста ли эта версия дерева.
+func (t *PersistentFullBinaryTree[T]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Find ищет значение в дереве (BFS), не изменяя его.
+func (t *PersistentFullBinaryTree[T]) Find(value T) bool {
+	if t.root == nil {
+		return false
+	}
+	queue := []*pnode[T]{t.root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current.Data == value {
+			return true
+		}
+		if current.Left != nil {
+			queue = append(queue, current.Left)
+		}
+		if current.Right != nil {
+			queue = append(queue, current.Right)
+		}
+	}
+	return false
+}
+
+// Insert возвращает новую версию дерева с value, добавленным к первому найденному
+// листу (BFS), как и мутируемый FullBinaryTree.Insert. Клонируются только узлы
+// на пути от корня до этого листа; остальные поддеревья разделяются с предыдущей версией.
+func (t *PersistentFullBinaryTree[T]) Insert(value T) *PersistentFullBinaryTree[T] {
+	if t.root == nil {
+		return &PersistentFullBinaryTree[T]{root: &pnode[T]{Data: value}, size: 1}
+	}
+
+	path := findFirstLeafPath(t.root)
+	newRoot := cloneWithPatch(t.root, path, func(n *pnode[T]) *pnode[T] {
+		return &pnode[T]{
+			Data:  n.Data,
+			Left:  &pnode[T]{Data: value},
+			Right: &pnode[T]{Data: value},
+		}
+	})
+	return &PersistentFullBinaryTree[T]{root: newRoot, size: t.size + 2}
+}
+
+// Remove возвращает новую версию дерева без value, используя ту же стратегию
+// "заменить самым правым листом", что и FullBinaryTree.Remove. Если value
+// отсутствует, возвращает Snapshot() текущей версии без изменений.
+func (t *PersistentFullBinaryTree[T]) Remove(value T) *PersistentFullBinaryTree[T] {
+	if t.root == nil {
+		return t.Snapshot()
+	}
+
+	path, found := findPathTo(t.root, value)
+	if !found {
+		return t.Snapshot()
+	}
+
+	target := nodeAtPath(t.root, path)
+
+	// Случай А: удаляемый узел - лист
+	if target.Left == nil && target.Right == nil {
+		if len(path) == 0 {
+			return &PersistentFullBinaryTree[T]{}
+		}
+		parentPath := path[:len(path)-1]
+		newRoot := cloneWithPatch(t.root, parentPath, func(n *pnode[T]) *pnode[T] {
+			return &pnode[T]{Data: n.Data}
+		})
+		return &PersistentFullBinaryTree[T]{root: newRoot, size: t.size - 2}
+	}
+
+	// Случай Б: внутренний узел - заменяем данными самого правого листа
+	// и удаляем пару детей у его родителя.
+	rightmostPath := findRightmostLeafPath(t.root)
+	newData := nodeAtPath(t.root, rightmostPath).Data
+
+	tree1Root := cloneWithPatch(t.root, path, func(n *pnode[T]) *pnode[T] {
+		return &pnode[T]{Data: newData, Left: n.Left, Right: n.Right}
+	})
+
+	rightmostParentPath := rightmostPath[:len(rightmostPath)-1]
+	finalRoot := cloneWithPatch(tree1Root, rightmostParentPath, func(n *pnode[T]) *pnode[T] {
+		return &pnode[T]{Data: n.Data}
+	})
+
+	return &PersistentFullBinaryTree[T]{root: finalRoot, size: t.size - 2}
+}
+
+// cloneWithPatch возвращает новое дерево, в котором узел по указанному пути
+// (последовательность false=Left/true=Right от корня) заменен на patch(original),
+// а все узлы на пути до него клонированы для сохранения неизменности. Поддеревья
+// вне пути (братья) разделяются с исходным деревом без копирования.
+func cloneWithPatch[T any](n *pnode[T], path []bool, patch func(*pnode[T]) *pnode[T]) *pnode[T] {
+	if len(path) == 0 {
+		return patch(n)
+	}
+	clone := &pnode[T]{Data: n.Data}
+	if !path[0] {
+		clone.Left = cloneWithPatch(n.Left, path[1:], patch)
+		clone.Right = n.Right
+	} else {
+		clone.Right = cloneWithPatch(n.Right, path[1:], patch)
+		clone.Left = n.Left
+	}
+	return clone
+}
+
+// nodeAtPath возвращает узел, достижимый из root по path.
+func nodeAtPath[T any](n *pnode[T], path []bool) *pnode[T] {
+	for _, goRight := range path {
+		if goRight {
+			n = n.Right
+		} else {
+			n = n.Left
+		}
+	}
+	return n
+}
+
+// findFirstLeafPath находит путь (BFS) до первого листа дерева.
+func findFirstLeafPath[T any](root *pnode[T]) []bool {
+	type item struct {
+		n    *pnode[T]
+		path []bool
+	}
+	queue := []item{{n: root}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.n.Left == nil && cur.n.Right == nil {
+			return cur.path
+		}
+		if cur.n.Left != nil {
+			queue = append(queue, item{n: cur.n.Left, path: appendPath(cur.path, false)})
+		}
+		if cur.n.Right != nil {
+			queue = append(queue, item{n: cur.n.Right, path: appendPath(cur.path, true)})
+		}
+	}
+	return nil
+}
+
+// findRightmostLeafPath находит путь (BFS) до самого нижнего правого листа дерева
+// (последний лист, встреченный при обходе в порядке уровней).
+func findRightmostLeafPath[T any](root *pnode[T]) []bool {
+	type item struct {
+		n    *pnode[T]
+		path []bool
+	}
+	var rightmost []bool
+	queue := []item{{n: root}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.n.Left == nil && cur.n.Right == nil {
+			rightmost = cur.path
+		}
+		if cur.n.Left != nil {
+			queue = append(queue, item{n: cur.n.Left, path: appendPath(cur.path, false)})
+		}
+		if cur.n.Right != nil {
+			queue = append(queue, item{n: cur.n.Right, path: appendPath(cur.path, true)})
+		}
+	}
+	return rightmost
+}
+
+// findPathTo находит путь (BFS) до первого узла с заданным значением.
+func findPathTo[T comparable](root *pnode[T], value T) ([]bool, bool) {
+	type item struct {
+		n    *pnode[T]
+		path []bool
+	}
+	queue := []item{{n: root}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.n.Data == value {
+			return cur.path, true
+		}
+		if cur.n.Left != nil {
+			queue = append(queue, item{n: cur.n.Left, path: appendPath(cur.path, false)})
+		}
+		if cur.n.Right != nil {
+			queue = append(queue, item{n: cur.n.Right, path: appendPath(cur.path, true)})
+		}
+	}
+	return nil, false
+}
+
+// appendPath возвращает копию path с добавленным шагом, не разделяя
+// backing-массив с другими ветками BFS-обхода.
+func appendPath(path []bool, step bool) []bool {
+	newPath := make([]bool, len(path)+1)
+	copy(newPath, path)
+	newPath[len(path)] = step
+	return newPath
+}
+
+// ToFullBinaryTree материализует эту версию в независимое мутируемое
+// FullBinaryTree (полная копия узлов), чтобы снимок можно было сериализовать
+// через существующие Serialize/SerializeText/SerializeTextTo без дублирования
+// этой логики здесь.
+func (t *PersistentFullBinaryTree[T]) ToFullBinaryTree() *FullBinaryTree[T] {
+	newTree := NewFullBinaryTree[T]()
+	newTree.size = t.size
+	newTree.root = pnodeToNode(t.root)
+	return newTree
+}
+
+func pnodeToNode[T any](n *pnode[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	return &node[T]{
+		Data:  n.Data,
+		Left:  pnodeToNode(n.Left),
+		Right: pnodeToNode(n.Right),
+	}
+}
+
+// ChangeKind различает вставку и удаление значения в Diff.
+type ChangeKind int
+
+const (
+	ChangeInsert ChangeKind = iota
+	ChangeRemove
+)
+
+func (k ChangeKind) String() string {
+	if k == ChangeInsert {
+		return "insert"
+	}
+	return "remove"
+}
+
+// Change описывает одно расхождение между двумя снимками: значение Value
+// было либо добавлено (ChangeInsert), либо удалено (ChangeRemove) при переходе
+// от t к other.
+type Change[T any] struct {
+	Kind  ChangeKind
+	Value T
+}
+
+// Diff сравнивает эту версию дерева с other и возвращает изменения,
+// превращающие t в other. Рекурсивный обход спускается по обоим корням
+// одновременно и сразу прекращает сравнение поддерева, как только указатели
+// на его корни совпадают: благодаря структурному разделению CoW-узлов
+// (cloneWithPatch клонирует только путь до измененного узла) совпадение
+// указателей гарантирует, что поддеревья идентичны, так что сравнивать их
+// содержимое не нужно.
+func (t *PersistentFullBinaryTree[T]) Diff(other *PersistentFullBinaryTree[T]) []Change[T] {
+	return diffPnode(t.root, other.root)
+}
+
+func diffPnode[T comparable](a, b *pnode[T]) []Change[T] {
+	if a == b {
+		return nil
+	}
+	if a == nil {
+		return collectChanges(b, ChangeInsert)
+	}
+	if b == nil {
+		return collectChanges(a, ChangeRemove)
+	}
+
+	var changes []Change[T]
+	if a.Data != b.Data {
+		changes = append(changes, Change[T]{Kind: ChangeRemove, Value: a.Data})
+		changes = append(changes, Change[T]{Kind: ChangeInsert, Value: b.Data})
+	}
+	changes = append(changes, diffPnode(a.Left, b.Left)...)
+	changes = append(changes, diffPnode(a.Right, b.Right)...)
+	return changes
+}
+
+// collectChanges обходит поддерево n (BFS) и оборачивает каждое значение
+// в Change с заданным kind; используется, когда одна из сторон Diff не имеет
+// соответствующего узла вовсе.
+func collectChanges[T any](n *pnode[T], kind ChangeKind) []Change[T] {
+	if n == nil {
+		return nil
+	}
+	var changes []Change[T]
+	queue := []*pnode[T]{n}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		changes = append(changes, Change[T]{Kind: kind, Value: cur.Data})
+		if cur.Left != nil {
+			queue = append(queue, cur.Left)
+		}
+		if cur.Right != nil {
+			queue = append(queue, cur.Right)
+		}
+	}
+	return changes
+}
+
+// Trees - реестр именованных снимков персистентного дерева, по аналогии
+// с моделью "дерево-лес" (tree-forest) из btrfstree: вместо одной текущей
+// версии вызывающий код хранит произвольное число снимков под собственными
+// идентификаторами и может вернуться к любому из них или сериализовать его.
+type Trees[ID comparable, T comparable] struct {
+	versions map[ID]*PersistentFullBinaryTree[T]
+}
+
+// NewTrees создает пустой реестр снимков.
+func NewTrees[ID comparable, T comparable]() *Trees[ID, T] {
+	return &Trees[ID, T]{versions: make(map[ID]*PersistentFullBinaryTree[T])}
+}
+
+// Save сохраняет снимок t под идентификатором id, заменяя предыдущий снимок
+// с тем же id, если он был.
+func (r *Trees[ID, T]) Save(id ID, t *PersistentFullBinaryTree[T]) {
+	r.versions[id] = t.Snapshot()
+}
+
+// Load возвращает снимок, сохраненный под id, и true, если он существует.
+func (r *Trees[ID, T]) Load(id ID) (*PersistentFullBinaryTree[T], bool) {
+	t, ok := r.versions[id]
+	return t, ok
+}
+
+// Delete удаляет снимок id из реестра.
+func (r *Trees[ID, T]) Delete(id ID) {
+	delete(r.versions, id)
+}
+
+// IDs возвращает идентификаторы всех сохраненных снимков в неопределенном порядке.
+func (r *Trees[ID, T]) IDs() []ID {
+	ids := make([]ID, 0, len(r.versions))
+	for id := range r.versions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Print выводит текущую версию дерева (BFS), аналогично FullBinaryTree.Print.
+func (t *PersistentFullBinaryTree[T]) Print() {
+	if t.root == nil {
+		fmt.Println("Empty tree")
+		return
+	}
+	fmt.Print("Level-order traversal: ")
+	queue := []*pnode[T]{t.root}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		fmt.Printf("%v ", curr.Data)
+		if curr.Left != nil {
+			queue = append(queue, curr.Left)
+		}
+		if curr.Right != nil {
+			queue = append(queue, curr.Right)
+		}
+	}
+	fmt.Println()
+}
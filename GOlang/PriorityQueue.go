@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Ошибки, которые могут возникнуть при работе с приоритетной очередью
+var (
+	ErrPriorityQueueEmpty = errors.New("priority queue is empty")
+)
+
+// Comparator определяет отношение приоритета для PriorityQueue: cmp(a, b) < 0
+// означает, что a имеет более высокий приоритет, чем b (должен выйти из
+// очереди раньше).
+type Comparator[T any] func(a, b T) int
+
+// PriorityQueue реализует приоритетную очередь на основе бинарной кучи
+// (неявный массив: родитель — (i-1)/2, дети — 2i+1 и 2i+2). cmp определяет
+// порядок: cmp(a, b) < 0 означает, что a имеет более высокий приоритет, чем b
+// (должен выйти из очереди раньше).
+// Zero-value для PriorityQueue не является валидным для использования,
+// используйте NewPriorityQueueWith().
+type PriorityQueue[T any] struct {
+	data []T
+	cmp  Comparator[T]
+}
+
+// NewPriorityQueueWith создает новую пустую приоритетную очередь с заданным компаратором.
+func NewPriorityQueueWith[T any](cmp Comparator[T]) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		data: make([]T, 0),
+		cmp:  cmp,
+	}
+}
+
+// Clone создает глубокую копию приоритетной очереди (компаратор переиспользуется).
+func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	newData := make([]T, len(pq.data))
+	copy(newData, pq.data)
+	return &PriorityQueue[T]{data: newData, cmp: pq.cmp}
+}
+
+// Enqueue добавляет элемент в очередь.
+// Сложность: O(log n).
+func (pq *PriorityQueue[T]) Enqueue(value T) {
+	pq.data = append(pq.data, value)
+	pq.siftUp(len(pq.data) - 1)
+}
+
+// Dequeue удаляет и возвращает элемент с наивысшим приоритетом.
+// Сложность: O(log n).
+func (pq *PriorityQueue[T]) Dequeue() (T, error) {
+	if len(pq.data) == 0 {
+		var zero T
+		return zero, ErrPriorityQueueEmpty
+	}
+	top := pq.data[0]
+	last := len(pq.data) - 1
+	pq.data[0] = pq.data[last]
+	pq.data = pq.data[:last]
+	if len(pq.data) > 0 {
+		pq.siftDown(0)
+	}
+	return top, nil
+}
+
+// Peek возвращает элемент с наивысшим приоритетом, не удаляя его.
+// Сложность: O(1).
+func (pq *PriorityQueue[T]) Peek() (T, error) {
+	if len(pq.data) == 0 {
+		var zero T
+		return zero, ErrPriorityQueueEmpty
+	}
+	return pq.data[0], nil
+}
+
+// Update заменяет элемент с индексом index на v и восстанавливает свойство
+// кучи за O(log n), перемещая элемент вверх или вниз в зависимости от того,
+// вырос или уменьшился его приоритет. index берется из порядка хранения кучи
+// (тот же порядок, что отдает Print), а не из порядка выхода из очереди.
+func (pq *PriorityQueue[T]) Update(index int, v T) error {
+	if index < 0 || index >= len(pq.data) {
+		return ErrIndexOutOfRange
+	}
+	old := pq.data[index]
+	pq.data[index] = v
+	if pq.cmp(v, old) < 0 {
+		pq.siftUp(index)
+	} else {
+		pq.siftDown(index)
+	}
+	return nil
+}
+
+// Remove удаляет элемент с индексом index (в порядке хранения кучи) и
+// восстанавливает свойство кучи за O(log n).
+func (pq *PriorityQueue[T]) Remove(index int) error {
+	if index < 0 || index >= len(pq.data) {
+		return ErrIndexOutOfRange
+	}
+	last := len(pq.data) - 1
+	pq.data[index] = pq.data[last]
+	pq.data = pq.data[:last]
+
+	if index < len(pq.data) {
+		pq.siftDown(index)
+		pq.siftUp(index)
+	}
+	return nil
+}
+
+// siftUp восстанавливает свойство кучи, поднимая элемент с индексом i вверх.
+func (pq *PriorityQueue[T]) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if pq.cmp(pq.data[i], pq.data[parent]) < 0 {
+			pq.data[i], pq.data[parent] = pq.data[parent], pq.data[i]
+			i = parent
+		} else {
+			break
+		}
+	}
+}
+
+// siftDown восстанавливает свойство кучи, опуская элемент с индексом i вниз.
+func (pq *PriorityQueue[T]) siftDown(i int) {
+	n := len(pq.data)
+	for {
+		left := 2*i + 1
+		right := 2*i + 2
+		smallest := i
+
+		if left < n && pq.cmp(pq.data[left], pq.data[smallest]) < 0 {
+			smallest = left
+		}
+		if right < n && pq.cmp(pq.data[right], pq.data[smallest]) < 0 {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		pq.data[i], pq.data[smallest] = pq.data[smallest], pq.data[i]
+		i = smallest
+	}
+}
+
+// Size возвращает текущее количество элементов.
+func (pq *PriorityQueue[T]) Size() int {
+	return len(pq.data)
+}
+
+// IsEmpty проверяет, пуста ли очередь.
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return len(pq.data) == 0
+}
+
+// Clear полностью очищает очередь.
+func (pq *PriorityQueue[T]) Clear() {
+	pq.data = make([]T, 0)
+}
+
+// Print выводит содержимое очереди (в порядке хранения кучи, не в порядке приоритета).
+func (pq *PriorityQueue[T]) Print() {
+	fmt.Print("PriorityQueue (heap order) [")
+	for i, v := range pq.data {
+		fmt.Print(v)
+		if i != len(pq.data)-1 {
+			fmt.Print(", ")
+		}
+	}
+	fmt.Println("]")
+}
+
+// Serialize сохраняет состояние очереди в бинарном формате (gob). Компаратор
+// не сериализуется — при десериализации используется компаратор целевой очереди.
+func (pq *PriorityQueue[T]) Serialize(w io.Writer) error {
+	enc := gob.NewEncoder(w)
+
+	if err := enc.Encode(len(pq.data)); err != nil {
+		return err
+	}
+	for _, v := range pq.data {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize восстанавливает очередь из бинарного формата (gob), заново
+// вставляя каждый элемент, чтобы гарантировать свойство кучи относительно
+// текущего компаратора.
+func (pq *PriorityQueue[T]) Deserialize(r io.Reader) error {
+	dec := gob.NewDecoder(r)
+	pq.Clear()
+
+	var n int
+	if err := dec.Decode(&n); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		var val T
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		pq.Enqueue(val)
+	}
+	return nil
+}
+
+// SerializeText сохраняет элементы в текстовом виде через пробел.
+func (pq *PriorityQueue[T]) SerializeText(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, len(pq.data)); err != nil {
+		return err
+	}
+	for i, v := range pq.data {
+		if _, err := fmt.Fprint(w, v); err != nil {
+			return err
+		}
+		if i != len(pq.data)-1 {
+			if _, err := fmt.Fprint(w, " "); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeserializeText считывает элементы из текстового потока.
+func (pq *PriorityQueue[T]) DeserializeText(r io.Reader) error {
+	pq.Clear()
+	var n int
+	if _, err := fmt.Fscan(r, &n); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		var val T
+		if _, err := fmt.Fscan(r, &val); err != nil {
+			return err
+		}
+		pq.Enqueue(val)
+	}
+	return nil
+}
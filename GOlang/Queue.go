@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/gob"
 	"errors"
 	"fmt"
@@ -173,26 +174,36 @@ func (q *Queue[T]) Deserialize(r io.Reader) error {
 }
 
 // SerializeText сохраняет элементы в текстовом виде через пробел.
+// Текст сперва собирается во временном буфере из bufferpool, а затем одним
+// Write уходит в w — это избавляет повторные сериализации от аллокации
+// нового буфера на каждый вызов.
 func (q *Queue[T]) SerializeText(w io.Writer) error {
-	if _, err := fmt.Fprintln(w, q.size); err != nil {
+	buf := bytes.NewBuffer(bufferPoolGet(q.size * 16))
+
+	if _, err := fmt.Fprintln(buf, q.size); err != nil {
 		return err
 	}
-	
+
 	current := q.frontNode
 	for current != nil {
-		if _, err := fmt.Fprint(w, current.data); err != nil {
+		if _, err := fmt.Fprint(buf, current.data); err != nil {
 			return err
 		}
 		if current.next != nil {
-			if _, err := fmt.Fprint(w, " "); err != nil {
+			if _, err := fmt.Fprint(buf, " "); err != nil {
 				return err
 			}
 		}
 		current = current.next
 	}
-	if _, err := fmt.Fprintln(w); err != nil {
+	if _, err := fmt.Fprintln(buf); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
 		return err
 	}
+	bufferPoolPut(buf.Bytes())
 	return nil
 }
 
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// RingQueue реализует структуру данных FIFO поверх растущего кольцевого
+// буфера (в отличие от Queue, построенного на связном списке). Это дает
+// O(1) индексированный доступ от головы (At) и диапазонную итерацию (Range),
+// которые связный список не может предложить эффективно.
+// Zero-value для RingQueue не является валидным для использования, используйте NewRingQueue().
+type RingQueue[T any] struct {
+	data []T
+	head int
+	size int
+}
+
+// NewRingQueue создает новую пустую очередь на кольцевом буфере емкостью initialCap
+// (минимум 1).
+func NewRingQueue[T any](initialCap int) *RingQueue[T] {
+	if initialCap < 1 {
+		initialCap = 1
+	}
+	return &RingQueue[T]{
+		data: make([]T, initialCap),
+		head: 0,
+		size: 0,
+	}
+}
+
+// Clone создает глубокую копию очереди.
+func (q *RingQueue[T]) Clone() *RingQueue[T] {
+	newQ := NewRingQueue[T](len(q.data))
+	for i := 0; i < q.size; i++ {
+		v, _ := q.At(i)
+		newQ.Enqueue(v)
+	}
+	return newQ
+}
+
+// grow удваивает емкость буфера, разворачивая wrap-область в новый буфер
+// двумя вызовами copy.
+func (q *RingQueue[T]) grow() {
+	newData := make([]T, len(q.data)*2)
+	if q.head+q.size <= len(q.data) {
+		copy(newData, q.data[q.head:q.head+q.size])
+	} else {
+		n := copy(newData, q.data[q.head:])
+		copy(newData[n:], q.data[:q.size-n])
+	}
+	q.data = newData
+	q.head = 0
+}
+
+// Enqueue добавляет элемент в конец очереди.
+// Амортизированная сложность: O(1).
+func (q *RingQueue[T]) Enqueue(element T) {
+	if q.size == len(q.data) {
+		q.grow()
+	}
+	tail := (q.head + q.size) % len(q.data)
+	q.data[tail] = element
+	q.size++
+}
+
+// Dequeue удаляет элемент из начала очереди.
+// Возвращает ошибку, если очередь пуста.
+// Сложность: O(1).
+func (q *RingQueue[T]) Dequeue() error {
+	if q.size == 0 {
+		return ErrQueueEmpty
+	}
+	var zero T
+	q.data[q.head] = zero // не удерживаем ссылку на ушедший элемент
+	q.head = (q.head + 1) % len(q.data)
+	q.size--
+	return nil
+}
+
+// Front возвращает первый элемент очереди.
+// Сложность: O(1).
+func (q *RingQueue[T]) Front() (T, error) {
+	if q.size == 0 {
+		var zero T
+		return zero, ErrQueueEmpty
+	}
+	return q.data[q.head], nil
+}
+
+// Back возвращает последний элемент очереди.
+// Сложность: O(1).
+func (q *RingQueue[T]) Back() (T, error) {
+	if q.size == 0 {
+		var zero T
+		return zero, ErrQueueEmpty
+	}
+	idx := (q.head + q.size - 1) % len(q.data)
+	return q.data[idx], nil
+}
+
+// At возвращает элемент с индексом i, считая от головы очереди (0 — Front).
+// Сложность: O(1).
+func (q *RingQueue[T]) At(i int) (T, error) {
+	if i < 0 || i >= q.size {
+		var zero T
+		return zero, fmt.Errorf("index %d out of range [0, %d)", i, q.size)
+	}
+	idx := (q.head + i) % len(q.data)
+	return q.data[idx], nil
+}
+
+// Range проходит по элементам с индексами [from, to), считая от головы
+// очереди, и вызывает fn(index, value) для каждого. Если fn вернет false,
+// обход останавливается досрочно.
+func (q *RingQueue[T]) Range(from, to int, fn func(int, T) bool) error {
+	if from < 0 || to > q.size || from > to {
+		return fmt.Errorf("range [%d, %d) out of bounds [0, %d)", from, to, q.size)
+	}
+	for i := from; i < to; i++ {
+		idx := (q.head + i) % len(q.data)
+		if !fn(i, q.data[idx]) {
+			break
+		}
+	}
+	return nil
+}
+
+// PeekN возвращает срез из следующих n элементов (от головы), не удаляя их
+// из очереди. Если n превышает текущий размер, возвращаются все элементы.
+func (q *RingQueue[T]) PeekN(n int) []T {
+	if n > q.size {
+		n = q.size
+	}
+	result := make([]T, n)
+	for i := 0; i < n; i++ {
+		idx := (q.head + i) % len(q.data)
+		result[i] = q.data[idx]
+	}
+	return result
+}
+
+// Size возвращает текущий размер очереди.
+func (q *RingQueue[T]) Size() int {
+	return q.size
+}
+
+// Capacity возвращает текущую емкость внутреннего буфера.
+func (q *RingQueue[T]) Capacity() int {
+	return len(q.data)
+}
+
+// IsEmpty проверяет, пуста ли очередь.
+func (q *RingQueue[T]) IsEmpty() bool {
+	return q.size == 0
+}
+
+// Clear полностью очищает очередь, сохраняя текущую емкость буфера.
+func (q *RingQueue[T]) Clear() {
+	q.data = make([]T, len(q.data))
+	q.head = 0
+	q.size = 0
+}
+
+// Print выводит содержимое очереди в stdout.
+func (q *RingQueue[T]) Print() {
+	fmt.Print("Front -> [")
+	for i := 0; i < q.size; i++ {
+		idx := (q.head + i) % len(q.data)
+		fmt.Print(q.data[idx])
+		if i != q.size-1 {
+			fmt.Print(", ")
+		}
+	}
+	fmt.Println("] <- Back")
+}
+
+// Serialize сохраняет состояние очереди в бинарном формате (gob).
+func (q *RingQueue[T]) Serialize(w io.Writer) error {
+	enc := gob.NewEncoder(w)
+
+	if err := enc.Encode(q.size); err != nil {
+		return err
+	}
+	for i := 0; i < q.size; i++ {
+		idx := (q.head + i) % len(q.data)
+		if err := enc.Encode(q.data[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize восстанавливает очередь из бинарного формата (gob).
+func (q *RingQueue[T]) Deserialize(r io.Reader) error {
+	dec := gob.NewDecoder(r)
+	q.Clear()
+
+	var newSize int
+	if err := dec.Decode(&newSize); err != nil {
+		return err
+	}
+
+	for i := 0; i < newSize; i++ {
+		var val T
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		q.Enqueue(val)
+	}
+	return nil
+}
+
+// SerializeText сохраняет элементы в текстовом виде через пробел.
+func (q *RingQueue[T]) SerializeText(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, q.size); err != nil {
+		return err
+	}
+
+	for i := 0; i < q.size; i++ {
+		idx := (q.head + i) % len(q.data)
+		if _, err := fmt.Fprint(w, q.data[idx]); err != nil {
+			return err
+		}
+		if i != q.size-1 {
+			if _, err := fmt.Fprint(w, " "); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeserializeText считывает элементы из текстового потока.
+func (q *RingQueue[T]) DeserializeText(r io.Reader) error {
+	q.Clear()
+	var newSize int
+	if _, err := fmt.Fscan(r, &newSize); err != nil {
+		return err
+	}
+
+	for i := 0; i < newSize; i++ {
+		var val T
+		if _, err := fmt.Fscan(r, &val); err != nil {
+			return err
+		}
+		q.Enqueue(val)
+	}
+	return nil
+}
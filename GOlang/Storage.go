@@ -0,0 +1,1061 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ErrKeyNotFound возвращается Storage.Get, когда ключ отсутствует.
+var ErrKeyNotFound = errors.New("storage: key not found")
+
+// Batch накапливает несколько Put/Delete для применения одним вызовом Commit.
+// Атомарность зависит от реализации: MemoryStorage применяет весь пакет под
+// одной блокировкой, а FileStorage, AppendLogStorage и ExternalKVStorage
+// применяют операции по одной, так что ошибка посреди Commit оставляет уже
+// примененные операции в хранилище.
+type Batch interface {
+	Put(key, value []byte)
+	Delete(key []byte)
+	Commit() error
+}
+
+// Storage абстрагирует персистентный backend ключ-значение, на котором строятся
+// Persistent-обертки над Array/Queue/Stack/HashTable. Помимо встроенного
+// MemoryStorage и FileStorage, интерфейс рассчитан на адаптеры поверх внешних
+// хранилищ (например, LevelDB или bbolt) — для этого достаточно реализовать
+// Storage, не меняя ни одной из Persistent-оберток.
+type Storage interface {
+	Get(key []byte) ([]byte, error)
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	Iterate(prefix []byte, fn func(key, value []byte) bool) error
+	Batch() Batch
+}
+
+// --- MemoryStorage: воспроизводит текущее (в памяти) поведение ---
+
+// MemoryStorage реализует Storage поверх map, защищенной мьютексом.
+type MemoryStorage struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+// NewMemoryStorage создает пустое in-memory хранилище.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{data: make(map[string][]byte)}
+}
+
+func (s *MemoryStorage) Get(key []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.data[string(key)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	out := make([]byte, len(v))
+	copy(out, v)
+	return out, nil
+}
+
+func (s *MemoryStorage) Put(key, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := make([]byte, len(value))
+	copy(v, value)
+	s.data[string(key)] = v
+	return nil
+}
+
+func (s *MemoryStorage) Delete(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, string(key))
+	return nil
+}
+
+func (s *MemoryStorage) Iterate(prefix []byte, fn func(key, value []byte) bool) error {
+	s.mu.RLock()
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		if bytes.HasPrefix([]byte(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	snapshot := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		snapshot[k] = s.data[k]
+	}
+	s.mu.RUnlock()
+
+	for _, k := range keys {
+		if !fn([]byte(k), snapshot[k]) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) Batch() Batch {
+	return &memoryBatch{s: s}
+}
+
+type memOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type memoryBatch struct {
+	s   *MemoryStorage
+	ops []memOp
+}
+
+func (b *memoryBatch) Put(key, value []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), value: append([]byte(nil), value...)})
+}
+
+func (b *memoryBatch) Delete(key []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), delete: true})
+}
+
+func (b *memoryBatch) Commit() error {
+	b.s.mu.Lock()
+	defer b.s.mu.Unlock()
+	for _, op := range b.ops {
+		if op.delete {
+			delete(b.s.data, string(op.key))
+		} else {
+			b.s.data[string(op.key)] = op.value
+		}
+	}
+	return nil
+}
+
+// --- FileStorage: один файл на ключ под базовой директорией ---
+
+// FileStorage реализует Storage, сохраняя каждое значение в отдельном файле
+// внутри dir (имя файла — ключ в hex-кодировке, чтобы избежать проблем с
+// разделителями пути). Это простейший способ пережить перезапуск процесса
+// без внешних зависимостей; настоящие LevelDB/bbolt-адаптеры подключаются
+// как отдельная реализация того же интерфейса Storage.
+type FileStorage struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileStorage создает (при необходимости) директорию dir и возвращает хранилище над ней.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+func (s *FileStorage) path(key []byte) string {
+	return filepath.Join(s.dir, hex.EncodeToString(key))
+}
+
+func (s *FileStorage) Get(key []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *FileStorage) Put(key, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return os.WriteFile(s.path(key), value, 0o644)
+}
+
+func (s *FileStorage) Delete(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := os.Remove(s.path(key))
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStorage) Iterate(prefix []byte, fn func(key, value []byte) bool) error {
+	s.mu.Lock()
+	entries, err := os.ReadDir(s.dir)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var keys [][]byte
+	for _, e := range entries {
+		key, decodeErr := hex.DecodeString(e.Name())
+		if decodeErr != nil || !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+
+	for _, key := range keys {
+		value, getErr := s.Get(key)
+		if getErr != nil {
+			continue
+		}
+		if !fn(key, value) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *FileStorage) Batch() Batch {
+	return &fileBatch{s: s}
+}
+
+type fileBatch struct {
+	s   *FileStorage
+	ops []memOp
+}
+
+func (b *fileBatch) Put(key, value []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), value: append([]byte(nil), value...)})
+}
+
+func (b *fileBatch) Delete(key []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), delete: true})
+}
+
+func (b *fileBatch) Commit() error {
+	for _, op := range b.ops {
+		if op.delete {
+			if err := b.s.Delete(op.key); err != nil {
+				return err
+			}
+		} else if err := b.s.Put(op.key, op.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- AppendLogStorage: один файл с append-only журналом и компакцией ---
+
+const (
+	alsOpPut    byte = 1
+	alsOpDelete byte = 2
+)
+
+// AppendLogStorage реализует Storage поверх одного append-only файла: каждый
+// Put/Delete дописывается в конец как запись [opcode][varint len(key)][key]
+// (для Put — еще [varint len(value)][value]), а текущее состояние держится в
+// памяти (mu+data, как MemoryStorage) в качестве индекса для Get/Iterate.
+// При открытии существующего файла журнал полностью проигрывается, восстанавливая
+// data — это дает crash-consistency: после сбоя (или простого закрытия
+// процесса) состояние восстанавливается по журналу на диске. Поскольку
+// журнал растет с каждой мутацией, Compact переписывает его во временный
+// файл, содержащий только текущие значения, и атомарно переименовывает его
+// на место исходного.
+type AppendLogStorage struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	data map[string][]byte
+}
+
+// NewAppendLogStorage открывает (создавая при необходимости) журнал по пути
+// path и восстанавливает состояние, проигрывая уже записанные в нем записи.
+func NewAppendLogStorage(path string) (*AppendLogStorage, error) {
+	s := &AppendLogStorage{path: path, data: make(map[string][]byte)}
+	if err := s.replay(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s.file = f
+	return s, nil
+}
+
+func (s *AppendLogStorage) replay() error {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	for {
+		op, err := br.ReadByte()
+		if err != nil {
+			break // EOF (в т.ч. обрыв на незавершенной последней записи после сбоя)
+		}
+		key, err := readLogBytes(br)
+		if err != nil {
+			break
+		}
+		switch op {
+		case alsOpPut:
+			value, err := readLogBytes(br)
+			if err != nil {
+				break
+			}
+			s.data[string(key)] = value
+		case alsOpDelete:
+			delete(s.data, string(key))
+		default:
+			return fmt.Errorf("append log: unknown opcode %d", op)
+		}
+	}
+	return nil
+}
+
+func readLogBytes(br *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func writeLogBytes(w io.Writer, b []byte) error {
+	varintBuf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(varintBuf, uint64(len(b)))
+	if _, err := w.Write(varintBuf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func (s *AppendLogStorage) appendRecord(op byte, key, value []byte) error {
+	if _, err := s.file.Write([]byte{op}); err != nil {
+		return err
+	}
+	if err := writeLogBytes(s.file, key); err != nil {
+		return err
+	}
+	if op == alsOpPut {
+		if err := writeLogBytes(s.file, value); err != nil {
+			return err
+		}
+	}
+	return s.file.Sync()
+}
+
+func (s *AppendLogStorage) Get(key []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[string(key)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	out := make([]byte, len(v))
+	copy(out, v)
+	return out, nil
+}
+
+func (s *AppendLogStorage) Put(key, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.appendRecord(alsOpPut, key, value); err != nil {
+		return err
+	}
+	v := make([]byte, len(value))
+	copy(v, value)
+	s.data[string(key)] = v
+	return nil
+}
+
+func (s *AppendLogStorage) Delete(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.data[string(key)]; !ok {
+		return nil
+	}
+	if err := s.appendRecord(alsOpDelete, key, nil); err != nil {
+		return err
+	}
+	delete(s.data, string(key))
+	return nil
+}
+
+func (s *AppendLogStorage) Iterate(prefix []byte, fn func(key, value []byte) bool) error {
+	s.mu.Lock()
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		if bytes.HasPrefix([]byte(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	snapshot := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		snapshot[k] = s.data[k]
+	}
+	s.mu.Unlock()
+
+	for _, k := range keys {
+		if !fn([]byte(k), snapshot[k]) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *AppendLogStorage) Batch() Batch {
+	return &appendLogBatch{s: s}
+}
+
+type appendLogBatch struct {
+	s   *AppendLogStorage
+	ops []memOp
+}
+
+func (b *appendLogBatch) Put(key, value []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), value: append([]byte(nil), value...)})
+}
+
+func (b *appendLogBatch) Delete(key []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), delete: true})
+}
+
+func (b *appendLogBatch) Commit() error {
+	for _, op := range b.ops {
+		if op.delete {
+			if err := b.s.Delete(op.key); err != nil {
+				return err
+			}
+		} else if err := b.s.Put(op.key, op.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compact переписывает журнал, оставляя только текущие значения (по одной
+// Put-записи на ключ), что отбрасывает историю перезаписей и tombstone'ы
+// удалений. Новый файл пишется во временный путь и атомарно переименовывается
+// поверх исходного, поэтому сбой посреди компакции не повреждает старый журнал.
+func (s *AppendLogStorage) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmpPath := s.path + ".compact.tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, err := tmp.Write([]byte{alsOpPut}); err != nil {
+			tmp.Close()
+			return err
+		}
+		if err := writeLogBytes(tmp, []byte(k)); err != nil {
+			tmp.Close()
+			return err
+		}
+		if err := writeLogBytes(tmp, s.data[k]); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	s.file = f
+	return nil
+}
+
+// Close закрывает файл журнала. Не входит в интерфейс Storage — не все его
+// реализации держат открытые хэндлы (MemoryStorage, FileStorage), поэтому
+// закрытие решено оставить отдельным io.Closer, на который вызывающий код
+// может type-assert при необходимости, а не раздувать интерфейс Storage
+// методом-заглушкой для всех реализаций.
+func (s *AppendLogStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// --- ExternalKVStorage: адаптер Storage поверх внешнего KV-драйвера ---
+
+// KVBackend — минимальная поверхность, которую должен предоставлять внешний
+// упорядоченный key-value движок (например, LevelDB или bbolt), чтобы за ним
+// можно было спрятать ExternalKVStorage. Пакет намеренно не зависит от
+// конкретного драйвера (в этой сборке нет менеджера зависимостей для его
+// подключения) — вызывающий код оборачивает хэндл своей библиотеки в
+// реализацию KVBackend, и ExternalKVStorage делает ее полноценным Storage.
+type KVBackend interface {
+	// Get возвращает (nil, nil), если ключ отсутствует — преобразование в
+	// ErrKeyNotFound делает ExternalKVStorage.
+	Get(key []byte) ([]byte, error)
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	NewIterator(prefix []byte) KVIterator
+}
+
+// KVIterator — курсор обхода KVBackend в порядке ключей, ограниченный префиксом.
+type KVIterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Release()
+}
+
+// ExternalKVStorage адаптирует KVBackend к интерфейсу Storage.
+type ExternalKVStorage struct {
+	backend KVBackend
+}
+
+// NewExternalKVStorage оборачивает backend в Storage.
+func NewExternalKVStorage(backend KVBackend) *ExternalKVStorage {
+	return &ExternalKVStorage{backend: backend}
+}
+
+func (s *ExternalKVStorage) Get(key []byte) ([]byte, error) {
+	v, err := s.backend.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrKeyNotFound
+	}
+	return v, nil
+}
+
+func (s *ExternalKVStorage) Put(key, value []byte) error { return s.backend.Put(key, value) }
+
+func (s *ExternalKVStorage) Delete(key []byte) error { return s.backend.Delete(key) }
+
+func (s *ExternalKVStorage) Iterate(prefix []byte, fn func(key, value []byte) bool) error {
+	it := s.backend.NewIterator(prefix)
+	defer it.Release()
+	for it.Next() {
+		if !fn(it.Key(), it.Value()) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *ExternalKVStorage) Batch() Batch {
+	return &externalKVBatch{s: s}
+}
+
+type externalKVBatch struct {
+	s   *ExternalKVStorage
+	ops []memOp
+}
+
+func (b *externalKVBatch) Put(key, value []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), value: append([]byte(nil), value...)})
+}
+
+func (b *externalKVBatch) Delete(key []byte) {
+	b.ops = append(b.ops, memOp{key: append([]byte(nil), key...), delete: true})
+}
+
+func (b *externalKVBatch) Commit() error {
+	for _, op := range b.ops {
+		if op.delete {
+			if err := b.s.Delete(op.key); err != nil {
+				return err
+			}
+		} else if err := b.s.Put(op.key, op.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Вспомогательная gob-кодировка значений для Persistent-оберток ---
+
+func storageEncode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func storageDecode(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// --- PersistentArray ---
+
+// PersistentArray реализует тот же набор операций, что и Array, но хранит
+// элементы в Storage по ключу "<ns>/<index>" вместо собственного буфера в памяти.
+type PersistentArray[T any] struct {
+	storage Storage
+	ns      string
+	size    int
+}
+
+// NewPersistentArray создает обертку над существующим пространством имен ns
+// в s, восстанавливая размер, если там уже есть данные.
+func NewPersistentArray[T any](s Storage, ns string) *PersistentArray[T] {
+	pa := &PersistentArray[T]{storage: s, ns: ns}
+	if raw, err := s.Get(pa.sizeKey()); err == nil {
+		var sz int
+		if storageDecode(raw, &sz) == nil {
+			pa.size = sz
+		}
+	}
+	return pa
+}
+
+func (pa *PersistentArray[T]) sizeKey() []byte {
+	return []byte(pa.ns + "/size")
+}
+
+func (pa *PersistentArray[T]) elemKey(i int) []byte {
+	return []byte(fmt.Sprintf("%s/%d", pa.ns, i))
+}
+
+func (pa *PersistentArray[T]) saveSize() error {
+	data, err := storageEncode(pa.size)
+	if err != nil {
+		return err
+	}
+	return pa.storage.Put(pa.sizeKey(), data)
+}
+
+// Add добавляет элемент в конец массива.
+func (pa *PersistentArray[T]) Add(element T) error {
+	data, err := storageEncode(element)
+	if err != nil {
+		return err
+	}
+	if err := pa.storage.Put(pa.elemKey(pa.size), data); err != nil {
+		return err
+	}
+	pa.size++
+	return pa.saveSize()
+}
+
+// AddAll добавляет elements в конец массива одной Batch-транзакцией вместо
+// отдельного Put+saveSize на каждый элемент, как делал бы повторный Add.
+func (pa *PersistentArray[T]) AddAll(elements []T) error {
+	if len(elements) == 0 {
+		return nil
+	}
+	batch := pa.storage.Batch()
+	for i, element := range elements {
+		data, err := storageEncode(element)
+		if err != nil {
+			return err
+		}
+		batch.Put(pa.elemKey(pa.size+i), data)
+	}
+	pa.size += len(elements)
+	sizeData, err := storageEncode(pa.size)
+	if err != nil {
+		return err
+	}
+	batch.Put(pa.sizeKey(), sizeData)
+	return batch.Commit()
+}
+
+// Get возвращает элемент по индексу.
+func (pa *PersistentArray[T]) Get(index int) (T, error) {
+	var zero T
+	if index < 0 || index >= pa.size {
+		return zero, errors.New("index out of range")
+	}
+	raw, err := pa.storage.Get(pa.elemKey(index))
+	if err != nil {
+		return zero, err
+	}
+	var v T
+	if err := storageDecode(raw, &v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// Set перезаписывает элемент по индексу.
+func (pa *PersistentArray[T]) Set(index int, element T) error {
+	if index < 0 || index >= pa.size {
+		return errors.New("index out of range")
+	}
+	data, err := storageEncode(element)
+	if err != nil {
+		return err
+	}
+	return pa.storage.Put(pa.elemKey(index), data)
+}
+
+// Size возвращает текущее количество элементов.
+func (pa *PersistentArray[T]) Size() int {
+	return pa.size
+}
+
+// IsEmpty проверяет, пуст ли массив.
+func (pa *PersistentArray[T]) IsEmpty() bool {
+	return pa.size == 0
+}
+
+// --- PersistentQueue ---
+
+// PersistentQueue хранит элементы в Storage по монотонно растущим ключам
+// "<ns>/elem/<counter>" между head (следующий к извлечению) и tail (следующий
+// свободный слот).
+type PersistentQueue[T any] struct {
+	storage    Storage
+	ns         string
+	head, tail int
+}
+
+// NewPersistentQueue создает обертку над существующим пространством имен ns в s.
+func NewPersistentQueue[T any](s Storage, ns string) *PersistentQueue[T] {
+	pq := &PersistentQueue[T]{storage: s, ns: ns}
+	if raw, err := s.Get(pq.metaKey("head")); err == nil {
+		storageDecode(raw, &pq.head)
+	}
+	if raw, err := s.Get(pq.metaKey("tail")); err == nil {
+		storageDecode(raw, &pq.tail)
+	}
+	return pq
+}
+
+func (pq *PersistentQueue[T]) metaKey(name string) []byte {
+	return []byte(pq.ns + "/" + name)
+}
+
+func (pq *PersistentQueue[T]) elemKey(i int) []byte {
+	return []byte(fmt.Sprintf("%s/elem/%d", pq.ns, i))
+}
+
+func (pq *PersistentQueue[T]) saveMeta() error {
+	headData, err := storageEncode(pq.head)
+	if err != nil {
+		return err
+	}
+	if err := pq.storage.Put(pq.metaKey("head"), headData); err != nil {
+		return err
+	}
+	tailData, err := storageEncode(pq.tail)
+	if err != nil {
+		return err
+	}
+	return pq.storage.Put(pq.metaKey("tail"), tailData)
+}
+
+// Enqueue добавляет элемент в конец очереди.
+func (pq *PersistentQueue[T]) Enqueue(element T) error {
+	data, err := storageEncode(element)
+	if err != nil {
+		return err
+	}
+	if err := pq.storage.Put(pq.elemKey(pq.tail), data); err != nil {
+		return err
+	}
+	pq.tail++
+	return pq.saveMeta()
+}
+
+// Dequeue удаляет элемент из начала очереди.
+func (pq *PersistentQueue[T]) Dequeue() error {
+	if pq.head >= pq.tail {
+		return ErrQueueEmpty
+	}
+	if err := pq.storage.Delete(pq.elemKey(pq.head)); err != nil {
+		return err
+	}
+	pq.head++
+	return pq.saveMeta()
+}
+
+// Front возвращает первый элемент очереди.
+func (pq *PersistentQueue[T]) Front() (T, error) {
+	var zero T
+	if pq.head >= pq.tail {
+		return zero, ErrQueueEmpty
+	}
+	raw, err := pq.storage.Get(pq.elemKey(pq.head))
+	if err != nil {
+		return zero, err
+	}
+	var v T
+	if err := storageDecode(raw, &v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// Size возвращает текущий размер очереди.
+func (pq *PersistentQueue[T]) Size() int {
+	return pq.tail - pq.head
+}
+
+// IsEmpty проверяет, пуста ли очередь.
+func (pq *PersistentQueue[T]) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// Compact перепаковывает очередь: живые элементы переносятся с индексов
+// head..tail-1 на 0..Size()-1, после чего head/tail сбрасываются к 0/Size().
+// Без Compact head и tail растут монотонно на каждый Enqueue/Dequeue, так что
+// после долгой работы занятое пространство ключей в Storage становится
+// заметно больше фактического размера очереди. Перенос и сброс метаданных
+// применяются одним Batch.Commit; атомарен он только там, где атомарен Batch
+// самого Storage (см. Batch), — на остальных backend'ах сбой в середине может
+// оставить очередь частично перепакованной.
+func (pq *PersistentQueue[T]) Compact() error {
+	n := pq.Size()
+	batch := pq.storage.Batch()
+
+	for i := 0; i < n; i++ {
+		raw, err := pq.storage.Get(pq.elemKey(pq.head + i))
+		if err != nil {
+			return err
+		}
+		batch.Put(pq.elemKey(i), raw)
+	}
+	// Индексы старого диапазона [head, tail), не попавшие в новый [0, n),
+	// больше не нужны; индексы, попавшие в оба диапазона, уже перезаписаны
+	// выше правильным значением, так что повторно их удалять не нужно.
+	for j := pq.head; j < pq.tail; j++ {
+		if j >= n {
+			batch.Delete(pq.elemKey(j))
+		}
+	}
+
+	headData, err := storageEncode(0)
+	if err != nil {
+		return err
+	}
+	tailData, err := storageEncode(n)
+	if err != nil {
+		return err
+	}
+	batch.Put(pq.metaKey("head"), headData)
+	batch.Put(pq.metaKey("tail"), tailData)
+
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+	pq.head = 0
+	pq.tail = n
+	return nil
+}
+
+// --- PersistentStack ---
+
+// PersistentStack хранит элементы в Storage по ключам "<ns>/<index>", как PersistentArray,
+// но с доступом только к вершине (LIFO).
+type PersistentStack[T any] struct {
+	storage Storage
+	ns      string
+	size    int
+}
+
+// NewPersistentStack создает обертку над существующим пространством имен ns в s.
+func NewPersistentStack[T any](s Storage, ns string) *PersistentStack[T] {
+	ps := &PersistentStack[T]{storage: s, ns: ns}
+	if raw, err := s.Get(ps.sizeKey()); err == nil {
+		storageDecode(raw, &ps.size)
+	}
+	return ps
+}
+
+func (ps *PersistentStack[T]) sizeKey() []byte {
+	return []byte(ps.ns + "/size")
+}
+
+func (ps *PersistentStack[T]) elemKey(i int) []byte {
+	return []byte(fmt.Sprintf("%s/%d", ps.ns, i))
+}
+
+func (ps *PersistentStack[T]) saveSize() error {
+	data, err := storageEncode(ps.size)
+	if err != nil {
+		return err
+	}
+	return ps.storage.Put(ps.sizeKey(), data)
+}
+
+// Push кладет элемент на вершину стека.
+func (ps *PersistentStack[T]) Push(element T) error {
+	data, err := storageEncode(element)
+	if err != nil {
+		return err
+	}
+	if err := ps.storage.Put(ps.elemKey(ps.size), data); err != nil {
+		return err
+	}
+	ps.size++
+	return ps.saveSize()
+}
+
+// Pop удаляет элемент с вершины стека.
+func (ps *PersistentStack[T]) Pop() error {
+	if ps.size == 0 {
+		return ErrStackEmpty
+	}
+	ps.size--
+	if err := ps.storage.Delete(ps.elemKey(ps.size)); err != nil {
+		return err
+	}
+	return ps.saveSize()
+}
+
+// Top возвращает элемент на вершине стека.
+func (ps *PersistentStack[T]) Top() (T, error) {
+	var zero T
+	if ps.size == 0 {
+		return zero, ErrStackEmpty
+	}
+	raw, err := ps.storage.Get(ps.elemKey(ps.size - 1))
+	if err != nil {
+		return zero, err
+	}
+	var v T
+	if err := storageDecode(raw, &v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// Size возвращает текущий размер стека.
+func (ps *PersistentStack[T]) Size() int {
+	return ps.size
+}
+
+// IsEmpty проверяет, пуст ли стек.
+func (ps *PersistentStack[T]) IsEmpty() bool {
+	return ps.size == 0
+}
+
+// --- PersistentHashTable ---
+
+// PersistentHashTable хранит пары ключ-значение в Storage под префиксом
+// "<ns>/kv/<key>", где <key> — строковое представление K (как в HashTable.hash).
+type PersistentHashTable[K comparable, V any] struct {
+	storage Storage
+	ns      string
+	size    int
+}
+
+// NewPersistentHashTable создает обертку над существующим пространством имен ns в s,
+// восстанавливая размер путем подсчета существующих записей под префиксом.
+func NewPersistentHashTable[K comparable, V any](s Storage, ns string) *PersistentHashTable[K, V] {
+	pht := &PersistentHashTable[K, V]{storage: s, ns: ns}
+	count := 0
+	s.Iterate([]byte(ns+"/kv/"), func(k, v []byte) bool {
+		count++
+		return true
+	})
+	pht.size = count
+	return pht
+}
+
+func (pht *PersistentHashTable[K, V]) keyBytes(key K) []byte {
+	return []byte(fmt.Sprintf("%s/kv/%v", pht.ns, key))
+}
+
+// Insert вставляет или обновляет пару ключ-значение.
+func (pht *PersistentHashTable[K, V]) Insert(key K, value V) error {
+	existed := pht.Find(key)
+	data, err := storageEncode(value)
+	if err != nil {
+		return err
+	}
+	if err := pht.storage.Put(pht.keyBytes(key), data); err != nil {
+		return err
+	}
+	if !existed {
+		pht.size++
+	}
+	return nil
+}
+
+// Get получает значение по ключу.
+func (pht *PersistentHashTable[K, V]) Get(key K) (V, error) {
+	var zero V
+	raw, err := pht.storage.Get(pht.keyBytes(key))
+	if err != nil {
+		return zero, err
+	}
+	var v V
+	if err := storageDecode(raw, &v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// Find проверяет наличие ключа в таблице.
+func (pht *PersistentHashTable[K, V]) Find(key K) bool {
+	_, err := pht.storage.Get(pht.keyBytes(key))
+	return err == nil
+}
+
+// Remove удаляет элемент по ключу.
+func (pht *PersistentHashTable[K, V]) Remove(key K) error {
+	if !pht.Find(key) {
+		return fmt.Errorf("key not found")
+	}
+	if err := pht.storage.Delete(pht.keyBytes(key)); err != nil {
+		return err
+	}
+	pht.size--
+	return nil
+}
+
+// GetSize возвращает текущее количество элементов.
+func (pht *PersistentHashTable[K, V]) GetSize() int {
+	return pht.size
+}
+
+// IsEmpty проверяет, пуста ли таблица.
+func (pht *PersistentHashTable[K, V]) IsEmpty() bool {
+	return pht.size == 0
+}
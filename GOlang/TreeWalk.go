@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+)
+
+// WalkSide указывает, с какой стороны родителя был достигнут узел при обходе.
+type WalkSide int
+
+const (
+	// SideRoot используется для корневого узла, у которого нет родителя.
+	SideRoot WalkSide = iota
+	SideLeft
+	SideRight
+)
+
+// PathElem хранит один шаг пути обхода: сам узел и сторону, с которой в него вошли.
+type PathElem[T any] struct {
+	Node *node[T]
+	Side WalkSide
+}
+
+// TreePath описывает путь от корня до текущего узла обхода (включительно).
+type TreePath[T any] []PathElem[T]
+
+// Node возвращает самый глубокий узел пути (текущий узел обхода) или nil для пустого пути.
+func (p TreePath[T]) Node() *node[T] {
+	if len(p) == 0 {
+		return nil
+	}
+	return p[len(p)-1].Node
+}
+
+// TreeWalkHandler собирает опциональные callback'и жизненного цикла обхода дерева.
+// Модель заимствована у обходчика дерева btrfs (btrfstree): PreNode/PostNode окружают
+// обработку самого узла, PreLeft/PostLeft и PreRight/PostRight окружают спуск в
+// соответствующее поддерево, а BadNode вызывается при обнаружении структурного
+// нарушения инварианта полного бинарного дерева. Любой ненулевой callback, вернувший
+// ошибку, немедленно прерывает обход.
+type TreeWalkHandler[T any] struct {
+	PreNode   func(path TreePath[T]) error
+	Node      func(path TreePath[T]) error
+	PostNode  func(path TreePath[T]) error
+	PreLeft   func(path TreePath[T]) error
+	PostLeft  func(path TreePath[T]) error
+	PreRight  func(path TreePath[T]) error
+	PostRight func(path TreePath[T]) error
+	BadNode   func(path TreePath[T], err error) error
+}
+
+// ErrNotFullNode сообщается в BadNode, когда у узла ровно один ребенок,
+// что нарушает инвариант полного бинарного дерева.
+var ErrNotFullNode = errors.New("node has exactly one child, violates full binary tree invariant")
+
+// TreeWalk обходит дерево в порядке pre-order (узел, левое поддерево, правое поддерево),
+// вызывая соответствующие callback'и из cbs на каждом шаге и передавая накопленный TreePath.
+// Обход прерывается досрочно, если ctx отменен или один из callback'ов вернул ошибку.
+//
+// Как и в io/fs.WalkDir, callback, вызванный до спуска в поддерево (PreNode, Node,
+// BadNode, PreLeft, PreRight), может вернуть io/fs.SkipDir: это не аборт всего
+// обхода, а отсечение текущего поддерева — TreeWalk продолжает обход с соседних
+// узлов так, как будто отсеченного поддерева не было.
+func (t *FullBinaryTree[T]) TreeWalk(ctx context.Context, cbs TreeWalkHandler[T]) error {
+	return treeWalkNode(ctx, t.root, nil, SideRoot, cbs)
+}
+
+// runPruningHook вызывает hook (если не nil) и сообщает, нужно ли отсечь
+// текущее поддерево (err == fs.SkipDir) либо прервать весь обход (любая другая ошибка).
+func runPruningHook[T any](hook func(TreePath[T]) error, path TreePath[T]) (prune bool, err error) {
+	if hook == nil {
+		return false, nil
+	}
+	err = hook(path)
+	if errors.Is(err, fs.SkipDir) {
+		return true, nil
+	}
+	return false, err
+}
+
+func treeWalkNode[T any](ctx context.Context, n *node[T], parentPath TreePath[T], side WalkSide, cbs TreeWalkHandler[T]) error {
+	if n == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path := make(TreePath[T], len(parentPath), len(parentPath)+1)
+	copy(path, parentPath)
+	path = append(path, PathElem[T]{Node: n, Side: side})
+
+	if prune, err := runPruningHook(cbs.PreNode, path); err != nil {
+		return err
+	} else if prune {
+		return nil
+	}
+
+	if (n.Left == nil) != (n.Right == nil) && cbs.BadNode != nil {
+		if prune, err := runPruningHook(func(p TreePath[T]) error { return cbs.BadNode(p, ErrNotFullNode) }, path); err != nil {
+			return err
+		} else if prune {
+			return nil
+		}
+	}
+
+	if prune, err := runPruningHook(cbs.Node, path); err != nil {
+		return err
+	} else if prune {
+		return nil
+	}
+
+	if n.Left != nil {
+		if prune, err := runPruningHook(cbs.PreLeft, path); err != nil {
+			return err
+		} else if !prune {
+			if err := treeWalkNode(ctx, n.Left, path, SideLeft, cbs); err != nil {
+				return err
+			}
+			if cbs.PostLeft != nil {
+				if err := cbs.PostLeft(path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if n.Right != nil {
+		if prune, err := runPruningHook(cbs.PreRight, path); err != nil {
+			return err
+		} else if !prune {
+			if err := treeWalkNode(ctx, n.Right, path, SideRight, cbs); err != nil {
+				return err
+			}
+			if cbs.PostRight != nil {
+				if err := cbs.PostRight(path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if cbs.PostNode != nil {
+		return cbs.PostNode(path)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"math/rand"
+	"sync"
 	"testing"
 )
 
@@ -187,6 +188,191 @@ func BenchmarkHashTable_Find(b *testing.B) {
 	}
 }
 
+func BenchmarkHashTableOpen_Insert(b *testing.B) {
+	ht := NewHashTableOpen[int, int]()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ht.Insert(i, i*2)
+	}
+}
+
+func BenchmarkHashTableOpen_Find(b *testing.B) {
+	ht := NewHashTableOpen[int, int]()
+	for i := 0; i < 10000; i++ {
+		ht.Insert(i, i*2)
+	}
+	rnd := rand.New(rand.NewSource(42))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := rnd.Intn(10000)
+		ht.Find(key)
+	}
+}
+
+// --- CONCURRENT HASHTABLE vs sync.Map BENCHMARKS (read-mostly) ---
+
+func BenchmarkConcurrentHashTable_ReadMostly(b *testing.B) {
+	ht := NewConcurrentHashTable[int, int]()
+	for i := 0; i < 10000; i++ {
+		ht.Insert(i, i*2)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		rnd := rand.New(rand.NewSource(42))
+		for pb.Next() {
+			ht.Get(rnd.Intn(10000))
+		}
+	})
+}
+
+func BenchmarkSyncMap_ReadMostly(b *testing.B) {
+	var m sync.Map
+	for i := 0; i < 10000; i++ {
+		m.Store(i, i*2)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		rnd := rand.New(rand.NewSource(42))
+		for pb.Next() {
+			m.Load(rnd.Intn(10000))
+		}
+	})
+}
+
+func BenchmarkRingQueue_Enqueue(b *testing.B) {
+	q := NewRingQueue[int](16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Enqueue(i)
+	}
+}
+
+func BenchmarkRingQueue_Dequeue(b *testing.B) {
+	q := NewRingQueue[int](b.N + 1)
+	for i := 0; i < b.N; i++ {
+		q.Enqueue(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Dequeue()
+	}
+}
+
+func BenchmarkRingQueue_At(b *testing.B) {
+	q := NewRingQueue[int](1024)
+	for i := 0; i < 1000; i++ {
+		q.Enqueue(i)
+	}
+	rnd := rand.New(rand.NewSource(42))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.At(rnd.Intn(1000))
+	}
+}
+
+func BenchmarkPriorityQueue_Enqueue(b *testing.B) {
+	pq := NewPriorityQueueWith(func(a, b int) int { return a - b })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Enqueue(i)
+	}
+}
+
+func BenchmarkPriorityQueue_Dequeue(b *testing.B) {
+	pq := NewPriorityQueueWith(func(a, b int) int { return a - b })
+	rnd := rand.New(rand.NewSource(42))
+	for i := 0; i < b.N; i++ {
+		pq.Enqueue(rnd.Int())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Dequeue()
+	}
+}
+
+func BenchmarkCircularBuffer_Push(b *testing.B) {
+	c := NewCircularBuffer[int](1024)
+	c.SetOverwrite(true)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Push(i)
+	}
+}
+
+func BenchmarkCircularBuffer_Pop(b *testing.B) {
+	c := NewCircularBuffer[int](nextPowerOfTwo(b.N + 1))
+	for i := 0; i < b.N; i++ {
+		c.Push(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Pop()
+	}
+}
+
+// --- ARTREE BENCHMARKS ---
+
+func randomStringKey(rnd *rand.Rand) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, 12)
+	for i := range b {
+		b[i] = letters[rnd.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func BenchmarkARTree_Insert(b *testing.B) {
+	tree := NewARTree[int]()
+	rnd := rand.New(rand.NewSource(42))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Insert([]byte(randomStringKey(rnd)), i)
+	}
+}
+
+func BenchmarkARTree_Get(b *testing.B) {
+	tree := NewARTree[int]()
+	rnd := rand.New(rand.NewSource(42))
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = randomStringKey(rnd)
+		tree.Insert([]byte(keys[i]), i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Get([]byte(keys[rnd.Intn(len(keys))]))
+	}
+}
+
+func BenchmarkHashTable_InsertString(b *testing.B) {
+	ht := NewHashTable[string, int]()
+	rnd := rand.New(rand.NewSource(42))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ht.Insert(randomStringKey(rnd), i)
+	}
+}
+
+func BenchmarkHashTable_FindString(b *testing.B) {
+	ht := NewHashTable[string, int]()
+	rnd := rand.New(rand.NewSource(42))
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = randomStringKey(rnd)
+		ht.Insert(keys[i], i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ht.Find(keys[rnd.Intn(len(keys))])
+	}
+}
+
 // --- FULL BINARY TREE BENCHMARKS ---
 
 func BenchmarkFullBinaryTree_Insert(b *testing.B) {
@@ -2,7 +2,13 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -279,24 +285,64 @@ func TestFullBinaryTree_Coverage(t *testing.T) {
 		tree := NewFullBinaryTree[int]()
 		tree.Insert(10)
 		tree.Insert(20)
-		
+		tree.Insert(30)
+
 		var buf bytes.Buffer
 		err := tree.SerializeText(&buf)
 		assertNoError(t, err, "SerializeText")
-		
+
 		// Проверяем что что-то записалось
 		content := buf.String()
 		if len(content) == 0 {
 			t.Error("SerializeText wrote nothing")
 		}
-		
-		// Десериализация (может не работать для сложных случаев)
-		// tree2 := NewFullBinaryTree[int]()
-		// reader := strings.NewReader(content)
-		// err = tree2.DeserializeText(reader)
-		// if err != nil {
-		//     t.Logf("DeserializeText failed (expected for generic impl): %v", err)
-		// }
+
+		tree2 := NewFullBinaryTree[int]()
+		err = tree2.DeserializeText(strings.NewReader(content))
+		assertNoError(t, err, "DeserializeText")
+		assertEqual(t, tree2.GetSize(), tree.GetSize(), "Size after text round-trip")
+		assertEqual(t, tree2.Find(10), true, "Find root after text round-trip")
+		assertEqual(t, tree2.Find(30), true, "Find leaf after text round-trip")
+	})
+
+	t.Run("SerializeCompact", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(10)
+		tree.Insert(20)
+		tree.Insert(30)
+
+		var buf bytes.Buffer
+		err := tree.SerializeCompact(&buf)
+		assertNoError(t, err, "SerializeCompact")
+
+		tree2 := NewFullBinaryTree[int]()
+		err = tree2.DeserializeCompact(&buf)
+		assertNoError(t, err, "DeserializeCompact")
+		assertEqual(t, tree2.GetSize(), tree.GetSize(), "Size after compact round-trip")
+		assertEqual(t, tree2.Find(30), true, "Find leaf after compact round-trip")
+
+		empty := NewFullBinaryTree[int]()
+		var emptyBuf bytes.Buffer
+		assertNoError(t, empty.SerializeCompact(&emptyBuf), "SerializeCompact empty")
+		empty2 := NewFullBinaryTree[int]()
+		assertNoError(t, empty2.DeserializeCompact(&emptyBuf), "DeserializeCompact empty")
+		assertEqual(t, empty2.IsEmpty(), true, "Empty tree round-trips as empty")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(10)
+		tree.Insert(20)
+		tree.Insert(30)
+
+		data, err := json.Marshal(tree)
+		assertNoError(t, err, "MarshalJSON")
+
+		tree2 := NewFullBinaryTree[int]()
+		err = json.Unmarshal(data, tree2)
+		assertNoError(t, err, "UnmarshalJSON")
+		assertEqual(t, tree2.GetSize(), tree.GetSize(), "Size after JSON round-trip")
+		assertEqual(t, tree2.Find(30), true, "Find leaf after JSON round-trip")
 	})
 
 	t.Run("Edge_cases", func(t *testing.T) {
@@ -517,6 +563,2233 @@ func TestStack_Coverage(t *testing.T) {
 	})
 }
 
+// TestPersistentContainers покрывает Storage-бэкенды и Persistent-обертки
+func TestPersistentContainers(t *testing.T) {
+	backends := map[string]func() Storage{
+		"Memory": func() Storage { return NewMemoryStorage() },
+		"File": func() Storage {
+			s, err := NewFileStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewFileStorage: %v", err)
+			}
+			return s
+		},
+		"AppendLog": func() Storage {
+			s, err := NewAppendLogStorage(filepath.Join(t.TempDir(), "log.db"))
+			if err != nil {
+				t.Fatalf("NewAppendLogStorage: %v", err)
+			}
+			return s
+		},
+	}
+
+	for name, makeStorage := range backends {
+		t.Run(name+"/Array", func(t *testing.T) {
+			s := makeStorage()
+			arr := NewPersistentArray[int](s, "arr")
+			assertNoError(t, arr.Add(10), "PersistentArray: add")
+			assertNoError(t, arr.Add(20), "PersistentArray: add")
+			assertEqual(t, arr.Size(), 2, "PersistentArray: size")
+
+			val, err := arr.Get(1)
+			assertNoError(t, err, "PersistentArray: get")
+			assertEqual(t, val, 20, "PersistentArray: get value")
+
+			assertNoError(t, arr.Set(0, 100), "PersistentArray: set")
+			val, _ = arr.Get(0)
+			assertEqual(t, val, 100, "PersistentArray: set value")
+
+			// Переоткрытие поверх того же пространства имен восстанавливает состояние
+			reopened := NewPersistentArray[int](s, "arr")
+			assertEqual(t, reopened.Size(), 2, "PersistentArray: reopen restores size")
+			val, _ = reopened.Get(0)
+			assertEqual(t, val, 100, "PersistentArray: reopen restores data")
+		})
+
+		t.Run(name+"/Queue", func(t *testing.T) {
+			s := makeStorage()
+			q := NewPersistentQueue[int](s, "q")
+			assertNoError(t, q.Enqueue(1), "PersistentQueue: enqueue")
+			assertNoError(t, q.Enqueue(2), "PersistentQueue: enqueue")
+
+			front, err := q.Front()
+			assertNoError(t, err, "PersistentQueue: front")
+			assertEqual(t, front, 1, "PersistentQueue: front value")
+
+			assertNoError(t, q.Dequeue(), "PersistentQueue: dequeue")
+			assertEqual(t, q.Size(), 1, "PersistentQueue: size after dequeue")
+
+			assertNoError(t, q.Dequeue(), "PersistentQueue: dequeue last")
+			err = q.Dequeue()
+			assertError(t, err, "PersistentQueue: dequeue empty")
+		})
+
+		t.Run(name+"/Stack", func(t *testing.T) {
+			s := makeStorage()
+			st := NewPersistentStack[int](s, "st")
+			assertNoError(t, st.Push(1), "PersistentStack: push")
+			assertNoError(t, st.Push(2), "PersistentStack: push")
+
+			top, err := st.Top()
+			assertNoError(t, err, "PersistentStack: top")
+			assertEqual(t, top, 2, "PersistentStack: top value")
+
+			assertNoError(t, st.Pop(), "PersistentStack: pop")
+			top, _ = st.Top()
+			assertEqual(t, top, 1, "PersistentStack: top after pop")
+		})
+
+		t.Run(name+"/HashTable", func(t *testing.T) {
+			s := makeStorage()
+			ht := NewPersistentHashTable[string, int](s, "ht")
+			assertNoError(t, ht.Insert("a", 1), "PersistentHashTable: insert")
+			assertNoError(t, ht.Insert("b", 2), "PersistentHashTable: insert")
+			assertEqual(t, ht.GetSize(), 2, "PersistentHashTable: size")
+
+			val, err := ht.Get("a")
+			assertNoError(t, err, "PersistentHashTable: get")
+			assertEqual(t, val, 1, "PersistentHashTable: get value")
+
+			assertNoError(t, ht.Remove("a"), "PersistentHashTable: remove")
+			if ht.Find("a") {
+				t.Error("PersistentHashTable: key should be gone after remove")
+			}
+			assertEqual(t, ht.GetSize(), 1, "PersistentHashTable: size after remove")
+		})
+	}
+
+	t.Run("MemoryStorage_Batch", func(t *testing.T) {
+		s := NewMemoryStorage()
+		b := s.Batch()
+		b.Put([]byte("k1"), []byte("v1"))
+		b.Put([]byte("k2"), []byte("v2"))
+		assertNoError(t, b.Commit(), "MemoryStorage: batch commit")
+
+		v, err := s.Get([]byte("k1"))
+		assertNoError(t, err, "MemoryStorage: get after batch")
+		assertEqual(t, string(v), "v1", "MemoryStorage: batch value")
+	})
+
+	t.Run("MemoryStorage_Iterate", func(t *testing.T) {
+		s := NewMemoryStorage()
+		s.Put([]byte("ns/1"), []byte("a"))
+		s.Put([]byte("ns/2"), []byte("b"))
+		s.Put([]byte("other/1"), []byte("c"))
+
+		var keys []string
+		s.Iterate([]byte("ns/"), func(k, v []byte) bool {
+			keys = append(keys, string(k))
+			return true
+		})
+		assertEqual(t, len(keys), 2, "MemoryStorage: iterate respects prefix")
+	})
+
+	t.Run("AppendLogStorage_CrashConsistency", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "log.db")
+
+		s, err := NewAppendLogStorage(path)
+		assertNoError(t, err, "NewAppendLogStorage")
+		assertNoError(t, s.Put([]byte("a"), []byte("1")), "AppendLogStorage: put a")
+		assertNoError(t, s.Put([]byte("b"), []byte("2")), "AppendLogStorage: put b")
+		assertNoError(t, s.Put([]byte("a"), []byte("3")), "AppendLogStorage: overwrite a")
+		assertNoError(t, s.Delete([]byte("b")), "AppendLogStorage: delete b")
+		assertNoError(t, s.Close(), "AppendLogStorage: close")
+
+		// Повторное открытие того же файла после Close эмулирует восстановление
+		// после перезапуска процесса: состояние должно быть проиграно из журнала на диске.
+		reopened, err := NewAppendLogStorage(path)
+		assertNoError(t, err, "AppendLogStorage: reopen")
+
+		v, err := reopened.Get([]byte("a"))
+		assertNoError(t, err, "AppendLogStorage: get a after reopen")
+		assertEqual(t, string(v), "3", "AppendLogStorage: a reflects last write after reopen")
+
+		_, err = reopened.Get([]byte("b"))
+		assertError(t, err, "AppendLogStorage: b should stay deleted after reopen")
+
+		assertNoError(t, reopened.Compact(), "AppendLogStorage: compact")
+		v, err = reopened.Get([]byte("a"))
+		assertNoError(t, err, "AppendLogStorage: get a after compact")
+		assertEqual(t, string(v), "3", "AppendLogStorage: a survives compact")
+		assertNoError(t, reopened.Close(), "AppendLogStorage: close after compact")
+
+		// Переоткрытие поверх скомпактированного журнала должно восстановить то же состояние.
+		afterCompact, err := NewAppendLogStorage(path)
+		assertNoError(t, err, "AppendLogStorage: reopen after compact")
+		v, err = afterCompact.Get([]byte("a"))
+		assertNoError(t, err, "AppendLogStorage: get a after reopening compacted log")
+		assertEqual(t, string(v), "3", "AppendLogStorage: a correct after reopening compacted log")
+		assertNoError(t, afterCompact.Close(), "AppendLogStorage: close")
+	})
+}
+
+// TestConcurrentHashTable покрывает базовые операции и конкурентный доступ
+func TestConcurrentHashTable(t *testing.T) {
+	t.Run("InsertGetRemove", func(t *testing.T) {
+		ht := NewConcurrentHashTable[int, string]()
+		if !ht.IsEmpty() {
+			t.Error("ConcurrentHashTable: expected empty")
+		}
+
+		ht.Insert(1, "one")
+		ht.Insert(2, "two")
+		assertEqual(t, ht.GetSize(), 2, "ConcurrentHashTable: size")
+
+		val, ok := ht.Get(1)
+		if !ok {
+			t.Error("ConcurrentHashTable: Get should find key 1")
+		}
+		assertEqual(t, val, "one", "ConcurrentHashTable: value check")
+
+		if !ht.Find(2) {
+			t.Error("ConcurrentHashTable: find existing")
+		}
+		if ht.Find(99) {
+			t.Error("ConcurrentHashTable: find non-existing")
+		}
+
+		ht.Insert(1, "ONE")
+		val, _ = ht.Get(1)
+		assertEqual(t, val, "ONE", "ConcurrentHashTable: update check")
+
+		err := ht.Remove(2)
+		assertNoError(t, err, "ConcurrentHashTable: remove")
+		if ht.Find(2) {
+			t.Error("ConcurrentHashTable: found removed element")
+		}
+
+		err = ht.Remove(999)
+		assertError(t, err, "ConcurrentHashTable: remove non-existent key")
+	})
+
+	t.Run("Rehash", func(t *testing.T) {
+		ht := NewConcurrentHashTable[int, int](2)
+		for i := 0; i < 100; i++ {
+			ht.Insert(i, i*2)
+		}
+		assertEqual(t, ht.GetSize(), 100, "ConcurrentHashTable: size after many inserts")
+		if ht.LoadFactor() > 0.75 {
+			t.Error("ConcurrentHashTable: load factor should stay bounded after rehash")
+		}
+		for i := 0; i < 100; i++ {
+			val, ok := ht.Get(i)
+			if !ok || val != i*2 {
+				t.Errorf("ConcurrentHashTable: missing or wrong value for key %d after rehash", i)
+			}
+		}
+	})
+
+	t.Run("ConcurrentReadsDuringWrites", func(t *testing.T) {
+		ht := NewConcurrentHashTable[int, int]()
+		var wg sync.WaitGroup
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				ht.Insert(i, i)
+			}
+		}()
+
+		for r := 0; r < 4; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					ht.Find(i)
+					ht.GetSize()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assertEqual(t, ht.GetSize(), 500, "ConcurrentHashTable: all writes landed")
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		ht := NewConcurrentHashTable[int, int]()
+		ht.Insert(1, 1)
+		ht.Clear()
+		assertEqual(t, ht.IsEmpty(), true, "ConcurrentHashTable: empty after clear")
+		assertEqual(t, ht.GetBucketCount(), 16, "ConcurrentHashTable: bucket count reset after clear")
+	})
+}
+
+// TestCodecs покрывает GobCodec/BinaryCodec/JSONCodec через EncodeWith/DecodeWith
+func TestCodecs(t *testing.T) {
+	codecs := []Codec{GobCodec{}, BinaryCodec{}, JSONCodec{}, JSONCodec{Pretty: true}}
+
+	for _, codec := range codecs {
+		codec := codec
+		t.Run("ForwardList_"+codec.Name(), func(t *testing.T) {
+			list := NewForwardList[int]()
+			list.PushBack(1)
+			list.PushBack(2)
+			list.PushBack(3)
+
+			var buf bytes.Buffer
+			err := list.EncodeWith(&buf, codec)
+			assertNoError(t, err, "EncodeWith "+codec.Name())
+
+			decoded := NewForwardList[int]()
+			err = decoded.DecodeWith(&buf, codec)
+			assertNoError(t, err, "DecodeWith "+codec.Name())
+
+			assertEqual(t, decoded.GetSize(), 3, "Codec round-trip size "+codec.Name())
+			front, _ := decoded.Front()
+			assertEqual(t, *front, 1, "Codec round-trip data "+codec.Name())
+		})
+
+		t.Run("DoubleList_"+codec.Name(), func(t *testing.T) {
+			list := NewDoubleList[int]()
+			list.PushBack(10)
+			list.PushBack(20)
+
+			var buf bytes.Buffer
+			err := list.EncodeWith(&buf, codec)
+			assertNoError(t, err, "EncodeWith "+codec.Name())
+
+			decoded := NewDoubleList[int]()
+			err = decoded.DecodeWith(&buf, codec)
+			assertNoError(t, err, "DecodeWith "+codec.Name())
+
+			assertEqual(t, decoded.GetSize(), 2, "Codec round-trip size "+codec.Name())
+		})
+	}
+
+	t.Run("FullBinaryTree_gob", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(1)
+		tree.Insert(2)
+
+		var buf bytes.Buffer
+		err := tree.EncodeWith(&buf, GobCodec{})
+		assertNoError(t, err, "Tree EncodeWith gob")
+
+		decoded := NewFullBinaryTree[int]()
+		err = decoded.DecodeWith(&buf, GobCodec{})
+		assertNoError(t, err, "Tree DecodeWith gob")
+		assertEqual(t, decoded.GetSize(), tree.GetSize(), "Tree codec round-trip size")
+	})
+
+	t.Run("BinaryCodec_rejects_non_slice", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(1)
+		var buf bytes.Buffer
+		err := tree.EncodeWith(&buf, BinaryCodec{})
+		assertError(t, err, "BinaryCodec should reject non-slice data")
+	})
+}
+
+// TestPersistentFullBinaryTree покрывает Insert/Remove/Snapshot и разделение структуры
+func TestPersistentFullBinaryTree(t *testing.T) {
+	t.Run("InsertKeepsOldVersion", func(t *testing.T) {
+		v0 := NewPersistentFullBinaryTree[int]()
+		v1 := v0.Insert(10)
+		v2 := v1.Insert(20)
+
+		assertEqual(t, v0.GetSize(), 0, "Persistent: v0 untouched")
+		assertEqual(t, v1.GetSize(), 1, "Persistent: v1 has one node")
+		assertEqual(t, v2.GetSize(), 3, "Persistent: v2 grew by 2 (full tree)")
+
+		if !v2.Find(10) || !v2.Find(20) {
+			t.Error("Persistent: v2 should contain both inserted values")
+		}
+		if v1.Find(20) {
+			t.Error("Persistent: v1 must not see values inserted into v2")
+		}
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		v1 := NewPersistentFullBinaryTree[int]().Insert(1).Insert(2)
+		snap := v1.Snapshot()
+		v2 := v1.Insert(3)
+
+		assertEqual(t, snap.GetSize(), v1.GetSize(), "Persistent: snapshot preserves size")
+		if v2.GetSize() == snap.GetSize() {
+			t.Error("Persistent: mutation of a later version must not affect the snapshot")
+		}
+	})
+
+	t.Run("RemoveLeaf", func(t *testing.T) {
+		v1 := NewPersistentFullBinaryTree[int]().Insert(1).Insert(2)
+		v2 := v1.Remove(2)
+
+		assertEqual(t, v2.GetSize(), v1.GetSize()-2, "Persistent: remove drops a leaf pair")
+		if v2.Find(2) {
+			t.Error("Persistent: removed value should be gone from new version")
+		}
+		if !v1.Find(2) {
+			t.Error("Persistent: removal must not mutate the prior version")
+		}
+	})
+
+	t.Run("RemoveMissingIsNoop", func(t *testing.T) {
+		v1 := NewPersistentFullBinaryTree[int]().Insert(1)
+		v2 := v1.Remove(999)
+		assertEqual(t, v2.GetSize(), v1.GetSize(), "Persistent: removing absent value is a no-op")
+	})
+}
+
+// TestForwardList_Iterator покрывает ForwardIterator и Range/All
+func TestForwardList_Iterator(t *testing.T) {
+	t.Run("TraverseAndValue", func(t *testing.T) {
+		list := NewForwardList[int]()
+		list.PushBack(1)
+		list.PushBack(2)
+		list.PushBack(3)
+
+		var got []int
+		for it := list.Begin(); it.Valid(); it.Next() {
+			got = append(got, *it.Value())
+		}
+		assertEqual(t, len(got), 3, "ForwardIterator: visits all elements")
+		assertEqual(t, got[0], 1, "ForwardIterator: first element")
+		assertEqual(t, got[2], 3, "ForwardIterator: last element")
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		list := NewForwardList[int]()
+		list.PushBack(1)
+		list.PushBack(2)
+		list.PushBack(3)
+
+		it := list.Begin()
+		it.Next() // на элементе 2
+		err := it.Remove()
+		assertNoError(t, err, "ForwardIterator: remove middle")
+		assertEqual(t, list.GetSize(), 2, "ForwardIterator: size after remove")
+		assertEqual(t, *it.Value(), 3, "ForwardIterator: iterator advances to next element")
+
+		endIt := list.End()
+		err = endIt.Remove()
+		assertError(t, err, "ForwardIterator: remove at End() fails")
+	})
+
+	t.Run("InsertBeforeAndAfter", func(t *testing.T) {
+		list := NewForwardList[int]()
+		list.PushBack(1)
+		list.PushBack(3)
+
+		it := list.Begin()
+		it.Next() // на элементе 3
+		err := it.InsertBefore(2)
+		assertNoError(t, err, "ForwardIterator: InsertBefore")
+
+		err = it.InsertAfter(4)
+		assertNoError(t, err, "ForwardIterator: InsertAfter")
+
+		var got []int
+		list.Range(func(v *int) bool {
+			got = append(got, *v)
+			return true
+		})
+		if len(got) != 4 || got[0] != 1 || got[1] != 2 || got[2] != 3 || got[3] != 4 {
+			t.Errorf("ForwardIterator: unexpected list after inserts: %v", got)
+		}
+
+		endIt := list.End()
+		err = endIt.InsertBefore(5)
+		assertNoError(t, err, "ForwardIterator: InsertBefore at End() appends")
+		assertEqual(t, list.GetSize(), 5, "ForwardIterator: size after append via InsertBefore")
+	})
+
+	t.Run("InsertBeforeThenRemove", func(t *testing.T) {
+		list := NewForwardList[int]()
+		list.PushBack(1)
+		list.PushBack(2)
+		list.PushBack(3)
+
+		it := list.Begin()
+		it.Next() // на элементе 2
+		err := it.InsertBefore(99)
+		assertNoError(t, err, "ForwardIterator: InsertBefore")
+
+		err = it.Remove()
+		assertNoError(t, err, "ForwardIterator: Remove after InsertBefore")
+
+		var got []int
+		list.Range(func(v *int) bool {
+			got = append(got, *v)
+			return true
+		})
+		if len(got) != 3 || got[0] != 1 || got[1] != 99 || got[2] != 3 {
+			t.Errorf("ForwardIterator: unexpected list after InsertBefore+Remove: %v", got)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		list := NewForwardList[int]()
+		list.PushBack(10)
+		list.PushBack(20)
+
+		sum := 0
+		for i, v := range list.All() {
+			sum += i + v
+		}
+		assertEqual(t, sum, 31, "ForwardList: All() yields index,value pairs")
+	})
+}
+
+// TestDoubleList_Iterator покрывает DoubleIterator и Range/All
+func TestDoubleList_Iterator(t *testing.T) {
+	t.Run("ForwardAndReverse", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		list.PushBack(1)
+		list.PushBack(2)
+		list.PushBack(3)
+
+		var fwd []int
+		for it := list.Begin(); it.Valid(); it.Next() {
+			fwd = append(fwd, *it.Value())
+		}
+		assertEqual(t, len(fwd), 3, "DoubleIterator: forward visits all elements")
+
+		var rev []int
+		for it := list.RBegin(); it.Valid(); it.Prev() {
+			rev = append(rev, *it.Value())
+		}
+		assertEqual(t, rev[0], 3, "DoubleIterator: reverse starts at tail")
+		assertEqual(t, rev[2], 1, "DoubleIterator: reverse ends at head")
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		list.PushBack(1)
+		list.PushBack(2)
+		list.PushBack(3)
+
+		it := list.Begin()
+		it.Next()
+		err := it.Remove()
+		assertNoError(t, err, "DoubleIterator: remove middle")
+		assertEqual(t, list.GetSize(), 2, "DoubleIterator: size after remove")
+
+		back, _ := list.Back()
+		assertEqual(t, *back, 3, "DoubleIterator: tail intact after remove")
+	})
+
+	t.Run("InsertBeforeAndAfter", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		list.PushBack(1)
+		list.PushBack(3)
+
+		it := list.Begin()
+		it.Next()
+		assertNoError(t, it.InsertBefore(2), "DoubleIterator: InsertBefore")
+		assertNoError(t, it.InsertAfter(4), "DoubleIterator: InsertAfter")
+
+		var got []int
+		list.Range(func(v *int) bool {
+			got = append(got, *v)
+			return true
+		})
+		if len(got) != 4 || got[0] != 1 || got[1] != 2 || got[2] != 3 || got[3] != 4 {
+			t.Errorf("DoubleIterator: unexpected list after inserts: %v", got)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		list.PushBack(10)
+		list.PushBack(20)
+
+		sum := 0
+		for i, v := range list.All() {
+			sum += i + v
+		}
+		assertEqual(t, sum, 31, "DoubleList: All() yields index,value pairs")
+	})
+}
+
+// TestForwardList_Sort покрывает Sort/SortStable/IsSorted/BubbleSortOptimized
+func TestForwardList_Sort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	t.Run("Sort", func(t *testing.T) {
+		list := NewForwardList[int]()
+		for _, v := range []int{5, 3, 4, 1, 2} {
+			list.PushBack(v)
+		}
+		list.Sort(less)
+		if !list.IsSorted(less) {
+			t.Error("ForwardList: Sort did not produce a sorted list")
+		}
+		front, _ := list.Front()
+		assertEqual(t, *front, 1, "ForwardList: Sort smallest first")
+		assertEqual(t, list.GetSize(), 5, "ForwardList: Sort preserves size")
+	})
+
+	t.Run("SortStable", func(t *testing.T) {
+		list := NewForwardList[int]()
+		for _, v := range []int{2, 1, 2, 1} {
+			list.PushBack(v)
+		}
+		list.SortStable(less)
+		if !list.IsSorted(less) {
+			t.Error("ForwardList: SortStable did not produce a sorted list")
+		}
+	})
+
+	t.Run("BubbleSortOptimized", func(t *testing.T) {
+		list := NewForwardList[int]()
+		for _, v := range []int{4, 2, 3, 1} {
+			list.PushBack(v)
+		}
+		list.BubbleSortOptimized(less)
+		if !list.IsSorted(less) {
+			t.Error("ForwardList: BubbleSortOptimized did not produce a sorted list")
+		}
+	})
+
+	t.Run("IsSorted_empty_and_single", func(t *testing.T) {
+		list := NewForwardList[int]()
+		if !list.IsSorted(less) {
+			t.Error("ForwardList: empty list should be sorted")
+		}
+		list.PushBack(1)
+		if !list.IsSorted(less) {
+			t.Error("ForwardList: single-element list should be sorted")
+		}
+	})
+}
+
+// TestDoubleList_Sort покрывает Sort/SortStable/IsSorted/BubbleSortOptimized
+func TestDoubleList_Sort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	t.Run("Sort", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		for _, v := range []int{5, 3, 4, 1, 2} {
+			list.PushBack(v)
+		}
+		list.Sort(less)
+		if !list.IsSorted(less) {
+			t.Error("DoubleList: Sort did not produce a sorted list")
+		}
+		front, _ := list.Front()
+		back, _ := list.Back()
+		assertEqual(t, *front, 1, "DoubleList: Sort smallest first")
+		assertEqual(t, *back, 5, "DoubleList: Sort largest last")
+		assertEqual(t, list.GetSize(), 5, "DoubleList: Sort preserves size")
+
+		// Prev должен быть восстановлен корректно (обход в обратную сторону)
+		list.PrintReverse()
+	})
+
+	t.Run("BubbleSortOptimized", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		for _, v := range []int{4, 2, 3, 1} {
+			list.PushBack(v)
+		}
+		list.BubbleSortOptimized(less)
+		if !list.IsSorted(less) {
+			t.Error("DoubleList: BubbleSortOptimized did not produce a sorted list")
+		}
+	})
+
+	t.Run("IsSorted_empty_and_single", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		if !list.IsSorted(less) {
+			t.Error("DoubleList: empty list should be sorted")
+		}
+		list.PushBack(1)
+		if !list.IsSorted(less) {
+			t.Error("DoubleList: single-element list should be sorted")
+		}
+	})
+}
+
+// TestFullBinaryTree_TreeWalk покрывает TreeWalk и его lifecycle callback'и
+func TestFullBinaryTree_TreeWalk(t *testing.T) {
+	t.Run("VisitOrderAndPath", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(10)
+		tree.Insert(20) // 10 -> (L:20, R:20)
+
+		var visited []int
+		var maxDepth int
+		err := tree.TreeWalk(context.Background(), TreeWalkHandler[int]{
+			Node: func(path TreePath[int]) error {
+				visited = append(visited, path.Node().Data)
+				if len(path) > maxDepth {
+					maxDepth = len(path)
+				}
+				return nil
+			},
+		})
+		assertNoError(t, err, "TreeWalk: visit all nodes")
+		assertEqual(t, len(visited), 3, "TreeWalk: visits 3 nodes")
+		assertEqual(t, maxDepth, 2, "TreeWalk: path depth at leaves")
+	})
+
+	t.Run("EarlyExitError", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(1)
+		tree.Insert(2)
+		tree.Insert(3)
+
+		stop := errors.New("stop")
+		count := 0
+		err := tree.TreeWalk(context.Background(), TreeWalkHandler[int]{
+			Node: func(path TreePath[int]) error {
+				count++
+				return stop
+			},
+		})
+		if !errors.Is(err, stop) {
+			t.Errorf("TreeWalk: expected early-exit error, got %v", err)
+		}
+		assertEqual(t, count, 1, "TreeWalk: stops after first node")
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(1)
+		tree.Insert(2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := tree.TreeWalk(ctx, TreeWalkHandler[int]{})
+		assertError(t, err, "TreeWalk: cancelled context stops walk")
+	})
+
+	t.Run("EmptyTree", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		visited := false
+		err := tree.TreeWalk(context.Background(), TreeWalkHandler[int]{
+			Node: func(path TreePath[int]) error {
+				visited = true
+				return nil
+			},
+		})
+		assertNoError(t, err, "TreeWalk: empty tree")
+		assertEqual(t, visited, false, "TreeWalk: no nodes visited on empty tree")
+	})
+
+	t.Run("SkipDirPrunesSubtree", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(1)
+		tree.Insert(2) // 1 -> (L:2, R:2)
+		tree.Insert(3) // L:2 -> (L:3, R:3)
+
+		var visited []int
+		err := tree.TreeWalk(context.Background(), TreeWalkHandler[int]{
+			PreNode: func(path TreePath[int]) error {
+				if path.Node().Data == 2 {
+					return fs.SkipDir
+				}
+				return nil
+			},
+			Node: func(path TreePath[int]) error {
+				visited = append(visited, path.Node().Data)
+				return nil
+			},
+		})
+		assertNoError(t, err, "TreeWalk: SkipDir does not abort the walk")
+		assertEqual(t, len(visited), 1, "TreeWalk: only root visited, both 2-subtrees pruned")
+		assertEqual(t, visited[0], 1, "TreeWalk: root is visited before pruning")
+	})
+}
+
+func TestCompressedSerialization(t *testing.T) {
+	t.Run("ArrayGzipRoundTrip", func(t *testing.T) {
+		a := NewArray[int](4)
+		a.Add(1)
+		a.Add(2)
+		a.Add(3)
+
+		var buf bytes.Buffer
+		err := a.SerializeCompressed(&buf, CompressionGzip)
+		assertNoError(t, err, "SerializeCompressed: gzip encode")
+
+		restored := NewArray[int](0)
+		err = restored.DeserializeCompressed(&buf, CompressionGzip)
+		assertNoError(t, err, "DeserializeCompressed: gzip decode")
+		assertEqual(t, restored.Size(), 3, "DeserializeCompressed: restored size")
+		for i := 0; i < 3; i++ {
+			v, _ := restored.Get(i)
+			assertEqual(t, v, i+1, "DeserializeCompressed: restored element")
+		}
+	})
+
+	t.Run("StackNoneRoundTrip", func(t *testing.T) {
+		s := NewStack[string]()
+		s.Push("a")
+		s.Push("b")
+
+		var buf bytes.Buffer
+		err := s.SerializeCompressed(&buf, CompressionNone)
+		assertNoError(t, err, "SerializeCompressed: none encode")
+
+		restored := NewStack[string]()
+		err = restored.DeserializeCompressed(&buf, CompressionNone)
+		assertNoError(t, err, "DeserializeCompressed: none decode")
+		assertEqual(t, restored.Size(), 2, "DeserializeCompressed: restored stack size")
+	})
+
+	t.Run("QueueGzipRoundTrip", func(t *testing.T) {
+		q := NewQueue[int]()
+		q.Enqueue(10)
+		q.Enqueue(20)
+
+		var buf bytes.Buffer
+		err := q.SerializeCompressed(&buf, CompressionGzip)
+		assertNoError(t, err, "SerializeCompressed: queue gzip encode")
+
+		restored := NewQueue[int]()
+		err = restored.DeserializeCompressed(&buf, CompressionGzip)
+		assertNoError(t, err, "DeserializeCompressed: queue gzip decode")
+		assertEqual(t, restored.Size(), 2, "DeserializeCompressed: restored queue size")
+	})
+
+	t.Run("HashTableGzipRoundTrip", func(t *testing.T) {
+		ht := NewHashTable[string, int]()
+		ht.Insert("one", 1)
+		ht.Insert("two", 2)
+
+		var buf bytes.Buffer
+		err := ht.SerializeCompressed(&buf, CompressionGzip)
+		assertNoError(t, err, "SerializeCompressed: hash table gzip encode")
+
+		restored := NewHashTable[string, int]()
+		err = restored.DeserializeCompressed(&buf, CompressionGzip)
+		assertNoError(t, err, "DeserializeCompressed: hash table gzip decode")
+		v, err := restored.Get("two")
+		assertNoError(t, err, "DeserializeCompressed: restored key present")
+		assertEqual(t, v, 2, "DeserializeCompressed: restored value")
+	})
+
+	t.Run("UnavailableCodecErrors", func(t *testing.T) {
+		a := NewArray[int](1)
+		a.Add(1)
+		var buf bytes.Buffer
+		err := a.SerializeCompressed(&buf, CompressionSnappy)
+		assertError(t, err, "SerializeCompressed: snappy not vendored")
+
+		err = a.SerializeCompressed(&buf, CompressionZstd)
+		assertError(t, err, "SerializeCompressed: zstd not vendored")
+	})
+
+	t.Run("CodecMismatchDetected", func(t *testing.T) {
+		a := NewArray[int](1)
+		a.Add(42)
+		var buf bytes.Buffer
+		err := a.SerializeCompressed(&buf, CompressionGzip)
+		assertNoError(t, err, "SerializeCompressed: gzip encode")
+
+		restored := NewArray[int](0)
+		err = restored.DeserializeCompressed(&buf, CompressionNone)
+		assertError(t, err, "DeserializeCompressed: codec mismatch detected")
+	})
+
+	t.Run("CorruptedStreamChecksumFails", func(t *testing.T) {
+		a := NewArray[int](1)
+		a.Add(7)
+		var buf bytes.Buffer
+		err := a.SerializeCompressed(&buf, CompressionNone)
+		assertNoError(t, err, "SerializeCompressed: none encode")
+
+		corrupted := buf.Bytes()
+		corrupted[len(corrupted)-1] ^= 0xFF
+
+		restored := NewArray[int](0)
+		err = restored.DeserializeCompressed(bytes.NewReader(corrupted), CompressionNone)
+		assertError(t, err, "DeserializeCompressed: corrupted stream detected")
+	})
+}
+
+func TestHashTableOpen_Coverage(t *testing.T) {
+	t.Run("NewHashTableOpen_with_capacity", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string](32)
+		assertEqual(t, ht.GetBucketCount(), 32, "Custom bucket count")
+
+		ht2 := NewHashTableOpen[int, string]()
+		assertEqual(t, ht2.GetBucketCount(), 16, "Default bucket count")
+	})
+
+	t.Run("InsertGetFind", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		ht.Insert(1, "one")
+		ht.Insert(2, "two")
+		ht.Insert(3, "three")
+
+		val, err := ht.Get(2)
+		assertNoError(t, err, "Get existing key")
+		assertEqual(t, val, "two", "Get returns correct value")
+		assertEqual(t, ht.Find(3), true, "Find existing key")
+		assertEqual(t, ht.Find(999), false, "Find missing key")
+		assertEqual(t, ht.GetSize(), 3, "GetSize after inserts")
+	})
+
+	t.Run("UpdateExistingKey", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		ht.Insert(1, "one")
+		ht.Insert(1, "uno")
+		val, _ := ht.Get(1)
+		assertEqual(t, val, "uno", "Insert overwrites existing key")
+		assertEqual(t, ht.GetSize(), 1, "Size unchanged after update")
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		for i := 0; i < 20; i++ {
+			ht.Insert(i, "value")
+		}
+		for i := 0; i < 20; i += 2 {
+			err := ht.Remove(i)
+			assertNoError(t, err, "Remove existing key")
+		}
+		for i := 0; i < 20; i++ {
+			found := ht.Find(i)
+			assertEqual(t, found, i%2 == 1, "Find after backward-shift deletion")
+		}
+		assertEqual(t, ht.GetSize(), 10, "Size after removals")
+	})
+
+	t.Run("Remove_nonexistent", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		err := ht.Remove(999)
+		assertError(t, err, "Remove non-existent key")
+	})
+
+	t.Run("Get_nonexistent", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		_, err := ht.Get(999)
+		assertError(t, err, "Get non-existent key")
+	})
+
+	t.Run("Rehash", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string](2)
+		for i := 0; i < 50; i++ {
+			ht.Insert(i, "value")
+		}
+		assertEqual(t, ht.LoadFactor() <= 0.85, true, "Load factor after rehash")
+		for i := 0; i < 50; i++ {
+			assertEqual(t, ht.Find(i), true, "Key survives rehash")
+		}
+	})
+
+	t.Run("IsEmpty_Clear", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		assertEqual(t, ht.IsEmpty(), true, "New table is empty")
+		ht.Insert(1, "one")
+		assertEqual(t, ht.IsEmpty(), false, "Non-empty after insert")
+		ht.Clear()
+		assertEqual(t, ht.IsEmpty(), true, "Empty after Clear")
+		assertEqual(t, ht.GetSize(), 0, "Size zero after Clear")
+	})
+
+	t.Run("Print", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		ht.Insert(1, "one")
+		ht.Print() // не должно падать
+
+		ht2 := NewHashTableOpen[int, string]()
+		ht2.Print() // пустая
+	})
+
+	t.Run("Serialize_Deserialize", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		ht.Insert(1, "one")
+		ht.Insert(2, "two")
+		ht.Insert(3, "three")
+
+		var buf bytes.Buffer
+		err := ht.Serialize(&buf)
+		assertNoError(t, err, "Serialize binary")
+
+		ht2 := NewHashTableOpen[int, string]()
+		err = ht2.Deserialize(&buf)
+		assertNoError(t, err, "Deserialize binary")
+
+		assertEqual(t, ht2.GetSize(), 3, "Size after deserialize")
+		val, _ := ht2.Get(1)
+		assertEqual(t, val, "one", "Data after deserialize")
+	})
+
+	t.Run("SerializeText_DeserializeText", func(t *testing.T) {
+		ht := NewHashTableOpen[int, string]()
+		ht.Insert(1, "one")
+		ht.Insert(2, "two")
+
+		var buf bytes.Buffer
+		err := ht.SerializeText(&buf)
+		assertNoError(t, err, "SerializeText")
+
+		ht2 := NewHashTableOpen[int, string]()
+		err = ht2.DeserializeText(&buf)
+		assertNoError(t, err, "DeserializeText")
+
+		assertEqual(t, ht2.GetSize(), 2, "Size after DeserializeText")
+		val, _ := ht2.Get(2)
+		assertEqual(t, val, "two", "Data after DeserializeText")
+	})
+}
+
+func TestRingQueue_Coverage(t *testing.T) {
+	t.Run("EnqueueDequeueFrontBack", func(t *testing.T) {
+		q := NewRingQueue[int](2)
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Enqueue(3) // должно вызвать grow
+
+		front, err := q.Front()
+		assertNoError(t, err, "Front")
+		assertEqual(t, front, 1, "Front value")
+
+		back, err := q.Back()
+		assertNoError(t, err, "Back")
+		assertEqual(t, back, 3, "Back value")
+
+		assertEqual(t, q.Size(), 3, "Size after enqueues")
+
+		err = q.Dequeue()
+		assertNoError(t, err, "Dequeue")
+		front, _ = q.Front()
+		assertEqual(t, front, 2, "Front after dequeue")
+	})
+
+	t.Run("WrapAroundGrow", func(t *testing.T) {
+		q := NewRingQueue[int](4)
+		for i := 0; i < 4; i++ {
+			q.Enqueue(i)
+		}
+		// Сдвигаем голову в середину буфера, затем снова заполняем до wrap.
+		q.Dequeue()
+		q.Dequeue()
+		q.Enqueue(4)
+		q.Enqueue(5) // tail оборачивается на начало буфера
+		q.Enqueue(6) // вызывает grow с развернутой областью
+
+		for i := 0; i < q.Size(); i++ {
+			v, err := q.At(i)
+			assertNoError(t, err, "At after wrap-around grow")
+			assertEqual(t, v, i+2, "value after wrap-around grow")
+		}
+	})
+
+	t.Run("At_OutOfRange", func(t *testing.T) {
+		q := NewRingQueue[int](4)
+		q.Enqueue(1)
+		_, err := q.At(5)
+		assertError(t, err, "At: index out of range")
+		_, err = q.At(-1)
+		assertError(t, err, "At: negative index")
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		q := NewRingQueue[int](4)
+		for i := 0; i < 5; i++ {
+			q.Enqueue(i)
+		}
+
+		var visited []int
+		err := q.Range(1, 4, func(i int, v int) bool {
+			visited = append(visited, v)
+			return true
+		})
+		assertNoError(t, err, "Range")
+		assertEqual(t, len(visited), 3, "Range visits expected count")
+		assertEqual(t, visited[0], 1, "Range first value")
+
+		var count int
+		_ = q.Range(0, 5, func(i int, v int) bool {
+			count++
+			return count < 2
+		})
+		assertEqual(t, count, 2, "Range early exit")
+
+		err = q.Range(0, 99, func(int, int) bool { return true })
+		assertError(t, err, "Range: out of bounds")
+	})
+
+	t.Run("PeekN", func(t *testing.T) {
+		q := NewRingQueue[int](4)
+		for i := 0; i < 3; i++ {
+			q.Enqueue(i)
+		}
+		peeked := q.PeekN(2)
+		assertEqual(t, len(peeked), 2, "PeekN returns requested count")
+		assertEqual(t, peeked[0], 0, "PeekN first value")
+		assertEqual(t, q.Size(), 3, "PeekN does not dequeue")
+
+		all := q.PeekN(100)
+		assertEqual(t, len(all), 3, "PeekN clamps to size")
+	})
+
+	t.Run("Dequeue_empty", func(t *testing.T) {
+		q := NewRingQueue[int](2)
+		err := q.Dequeue()
+		assertError(t, err, "Dequeue on empty queue")
+	})
+
+	t.Run("Front_Back_empty", func(t *testing.T) {
+		q := NewRingQueue[int](2)
+		_, err := q.Front()
+		assertError(t, err, "Front on empty queue")
+		_, err = q.Back()
+		assertError(t, err, "Back on empty queue")
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		q := NewRingQueue[int](4)
+		q.Enqueue(1)
+		q.Enqueue(2)
+		clone := q.Clone()
+		clone.Enqueue(3)
+		assertEqual(t, q.Size(), 2, "Original unaffected by clone mutation")
+		assertEqual(t, clone.Size(), 3, "Clone has extra element")
+	})
+
+	t.Run("Clear_IsEmpty_Print", func(t *testing.T) {
+		q := NewRingQueue[int](4)
+		assertEqual(t, q.IsEmpty(), true, "New queue is empty")
+		q.Enqueue(1)
+		q.Print() // не должно падать
+		q.Clear()
+		assertEqual(t, q.IsEmpty(), true, "Empty after Clear")
+		assertEqual(t, q.Capacity(), 4, "Capacity preserved after Clear")
+	})
+
+	t.Run("Serialize_Deserialize", func(t *testing.T) {
+		q := NewRingQueue[int](2)
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Enqueue(3)
+		q.Dequeue()
+
+		var buf bytes.Buffer
+		err := q.Serialize(&buf)
+		assertNoError(t, err, "Serialize binary")
+
+		q2 := NewRingQueue[int](2)
+		err = q2.Deserialize(&buf)
+		assertNoError(t, err, "Deserialize binary")
+		assertEqual(t, q2.Size(), 2, "Size after deserialize")
+		front, _ := q2.Front()
+		assertEqual(t, front, 2, "Front after deserialize")
+	})
+
+	t.Run("SerializeText_DeserializeText", func(t *testing.T) {
+		q := NewRingQueue[int](2)
+		q.Enqueue(1)
+		q.Enqueue(2)
+
+		var buf bytes.Buffer
+		err := q.SerializeText(&buf)
+		assertNoError(t, err, "SerializeText")
+
+		q2 := NewRingQueue[int](2)
+		err = q2.DeserializeText(&buf)
+		assertNoError(t, err, "DeserializeText")
+		assertEqual(t, q2.Size(), 2, "Size after DeserializeText")
+	})
+}
+
+func intMinCmp(a, b int) int { return a - b }
+
+func TestPriorityQueue_Coverage(t *testing.T) {
+	t.Run("EnqueueDequeueOrder", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(5)
+		pq.Enqueue(1)
+		pq.Enqueue(3)
+		pq.Enqueue(2)
+		pq.Enqueue(4)
+
+		var order []int
+		for pq.Size() > 0 {
+			v, err := pq.Dequeue()
+			assertNoError(t, err, "Dequeue")
+			order = append(order, v)
+		}
+		expected := []int{1, 2, 3, 4, 5}
+		for i, v := range expected {
+			assertEqual(t, order[i], v, "Dequeue order is by priority")
+		}
+	})
+
+	t.Run("Peek", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(10)
+		pq.Enqueue(2)
+		v, err := pq.Peek()
+		assertNoError(t, err, "Peek")
+		assertEqual(t, v, 2, "Peek returns highest priority")
+		assertEqual(t, pq.Size(), 2, "Peek does not remove")
+	})
+
+	t.Run("Dequeue_Peek_empty", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		_, err := pq.Dequeue()
+		assertError(t, err, "Dequeue on empty queue")
+		_, err = pq.Peek()
+		assertError(t, err, "Peek on empty queue")
+	})
+
+	t.Run("Clear_IsEmpty", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		assertEqual(t, pq.IsEmpty(), true, "New queue is empty")
+		pq.Enqueue(1)
+		assertEqual(t, pq.IsEmpty(), false, "Non-empty after enqueue")
+		pq.Clear()
+		assertEqual(t, pq.IsEmpty(), true, "Empty after Clear")
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(3)
+		pq.Enqueue(1)
+		clone := pq.Clone()
+		clone.Enqueue(0)
+		assertEqual(t, pq.Size(), 2, "Original unaffected by clone mutation")
+		v, _ := clone.Peek()
+		assertEqual(t, v, 0, "Clone reflects its own mutation")
+	})
+
+	t.Run("Print", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(1)
+		pq.Print() // не должно падать
+
+		pq2 := NewPriorityQueueWith(intMinCmp)
+		pq2.Print() // пустая
+	})
+
+	t.Run("Serialize_Deserialize", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(5)
+		pq.Enqueue(1)
+		pq.Enqueue(3)
+
+		var buf bytes.Buffer
+		err := pq.Serialize(&buf)
+		assertNoError(t, err, "Serialize binary")
+
+		pq2 := NewPriorityQueueWith(intMinCmp)
+		err = pq2.Deserialize(&buf)
+		assertNoError(t, err, "Deserialize binary")
+		assertEqual(t, pq2.Size(), 3, "Size after deserialize")
+		v, _ := pq2.Dequeue()
+		assertEqual(t, v, 1, "Heap property holds after deserialize")
+	})
+
+	t.Run("SerializeText_DeserializeText", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(5)
+		pq.Enqueue(1)
+
+		var buf bytes.Buffer
+		err := pq.SerializeText(&buf)
+		assertNoError(t, err, "SerializeText")
+
+		pq2 := NewPriorityQueueWith(intMinCmp)
+		err = pq2.DeserializeText(&buf)
+		assertNoError(t, err, "DeserializeText")
+		assertEqual(t, pq2.Size(), 2, "Size after DeserializeText")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(5)
+		pq.Enqueue(3)
+		pq.Enqueue(8)
+		pq.Enqueue(1)
+
+		err := pq.Update(0, 100)
+		assertNoError(t, err, "Update raises priority number (lowers priority)")
+
+		var order []int
+		for pq.Size() > 0 {
+			v, _ := pq.Dequeue()
+			order = append(order, v)
+		}
+		for i := 1; i < len(order); i++ {
+			assertEqual(t, order[i-1] <= order[i], true, "Heap property holds after Update")
+		}
+
+		err = pq.Update(0, 1)
+		assertError(t, err, "Update on empty queue is out of range")
+		err = pq.Update(-1, 1)
+		assertError(t, err, "Update with negative index is out of range")
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		pq := NewPriorityQueueWith(intMinCmp)
+		pq.Enqueue(5)
+		pq.Enqueue(3)
+		pq.Enqueue(8)
+		pq.Enqueue(1)
+		pq.Enqueue(9)
+
+		err := pq.Remove(0)
+		assertNoError(t, err, "Remove by heap index")
+		assertEqual(t, pq.Size(), 4, "Size after Remove")
+
+		var order []int
+		for pq.Size() > 0 {
+			v, _ := pq.Dequeue()
+			order = append(order, v)
+		}
+		for i := 1; i < len(order); i++ {
+			assertEqual(t, order[i-1] <= order[i], true, "Heap property holds after Remove")
+		}
+
+		err = pq.Remove(0)
+		assertError(t, err, "Remove on empty queue is out of range")
+		err = pq.Remove(100)
+		assertError(t, err, "Remove with out-of-range index")
+	})
+}
+
+func TestEnumerable_Coverage(t *testing.T) {
+	t.Run("ArrayIterator_ForwardAndReverse", func(t *testing.T) {
+		a := NewArray[int](4)
+		a.Add(10)
+		a.Add(20)
+		a.Add(30)
+
+		var forward []int
+		for it := a.Begin(); it.Valid(); it.Next() {
+			forward = append(forward, *it.Value())
+		}
+		assertEqual(t, len(forward), 3, "ArrayIterator: forward visits all elements")
+		assertEqual(t, forward[0], 10, "ArrayIterator: forward order")
+
+		it := a.End()
+		it.Prev()
+		assertEqual(t, *it.Value(), 30, "ArrayIterator: reverse from End")
+		assertEqual(t, it.Index(), 2, "ArrayIterator: Index tracks position")
+
+		it.Seek(0)
+		assertEqual(t, *it.Value(), 10, "ArrayIterator: Seek repositions")
+	})
+
+	t.Run("ForwardListIterator_Index", func(t *testing.T) {
+		l := NewForwardList[int]()
+		l.PushBack(1)
+		l.PushBack(2)
+		l.PushBack(3)
+
+		it := l.Begin()
+		assertEqual(t, it.Index(), 0, "ForwardIterator: Index starts at 0")
+		it.Next()
+		assertEqual(t, it.Index(), 1, "ForwardIterator: Index advances")
+
+		end := l.End()
+		assertEqual(t, end.Index(), 3, "ForwardIterator: End index equals size")
+	})
+
+	t.Run("DoubleListIterator_BidirectionalIndex", func(t *testing.T) {
+		l := NewDoubleList[int]()
+		l.PushBack(1)
+		l.PushBack(2)
+		l.PushBack(3)
+
+		it := l.RBegin()
+		assertEqual(t, it.Index(), 2, "DoubleIterator: RBegin index is size-1")
+		it.Prev()
+		assertEqual(t, it.Index(), 1, "DoubleIterator: Prev decrements index")
+		assertEqual(t, *it.Value(), 2, "DoubleIterator: Prev walks backward")
+	})
+
+	t.Run("QueueIterator", func(t *testing.T) {
+		q := NewQueue[int]()
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Enqueue(3)
+
+		var visited []int
+		for it := q.Begin(); it.Valid(); it.Next() {
+			visited = append(visited, *it.Value())
+		}
+		assertEqual(t, len(visited), 3, "QueueIterator: visits all elements")
+		assertEqual(t, visited[0], 1, "QueueIterator: front-to-back order")
+	})
+
+	t.Run("StackIterator", func(t *testing.T) {
+		s := NewStack[int]()
+		s.Push(1)
+		s.Push(2)
+		s.Push(3)
+
+		var visited []int
+		for it := s.Begin(); it.Valid(); it.Next() {
+			visited = append(visited, *it.Value())
+		}
+		assertEqual(t, len(visited), 3, "StackIterator: visits all elements")
+		assertEqual(t, visited[0], 3, "StackIterator: top-to-bottom order")
+	})
+
+	t.Run("HashTableIterator", func(t *testing.T) {
+		ht := NewHashTable[int, string]()
+		ht.Insert(1, "one")
+		ht.Insert(2, "two")
+		ht.Insert(3, "three")
+
+		seen := make(map[int]string)
+		for it := ht.Begin(); it.Valid(); it.Next() {
+			pair := it.Value()
+			seen[pair.Key] = pair.Value
+		}
+		assertEqual(t, len(seen), 3, "HashTableIterator: visits all entries")
+		assertEqual(t, seen[2], "two", "HashTableIterator: entry values correct")
+	})
+
+	t.Run("FullBinaryTreeIterator", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(2)
+		tree.Insert(1)
+
+		var visited []int
+		for it := tree.Begin(); it.Valid(); it.Next() {
+			visited = append(visited, *it.Value())
+		}
+		assertEqual(t, len(visited), 3, "FullBinaryTreeIterator: visits all nodes")
+
+		end := tree.End()
+		assertEqual(t, end.Index(), 3, "FullBinaryTreeIterator: End index equals node count")
+	})
+
+	t.Run("EnumerableHelpers", func(t *testing.T) {
+		a := NewArray[int](5)
+		for i := 1; i <= 5; i++ {
+			a.Add(i)
+		}
+
+		var sum int
+		Each[int](a.Begin(), func(v int) { sum += v })
+		assertEqual(t, sum, 15, "Each: sums all elements")
+
+		doubled := Map[int, int](a.Begin(), func(v int) int { return v * 2 })
+		assertEqual(t, doubled[4], 10, "Map: transforms each element")
+
+		even := Select[int](a.Begin(), func(v int) bool { return v%2 == 0 })
+		assertEqual(t, len(even), 2, "Select: filters elements")
+
+		assertEqual(t, Any[int](a.Begin(), func(v int) bool { return v == 3 }), true, "Any: finds matching element")
+		assertEqual(t, Any[int](a.Begin(), func(v int) bool { return v == 99 }), false, "Any: no matching element")
+
+		assertEqual(t, All[int](a.Begin(), func(v int) bool { return v > 0 }), true, "All: every element matches")
+		assertEqual(t, All[int](a.Begin(), func(v int) bool { return v > 1 }), false, "All: not every element matches")
+
+		found, ok := Find[int](a.Begin(), func(v int) bool { return v > 3 })
+		assertEqual(t, ok, true, "Find: found a matching element")
+		assertEqual(t, found, 4, "Find: returns first match")
+
+		_, ok = Find[int](a.Begin(), func(v int) bool { return v > 100 })
+		assertEqual(t, ok, false, "Find: no matching element")
+	})
+}
+
+func TestCircularBuffer_Coverage(t *testing.T) {
+	t.Run("PushPopPeek", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(1)
+		c.Push(2)
+		c.Push(3)
+
+		v, err := c.Peek()
+		assertNoError(t, err, "Peek")
+		assertEqual(t, v, 1, "Peek returns oldest element")
+		assertEqual(t, c.Size(), 3, "Peek does not remove")
+
+		v, err = c.Pop()
+		assertNoError(t, err, "Pop")
+		assertEqual(t, v, 1, "Pop returns oldest element")
+		assertEqual(t, c.Size(), 2, "Size after Pop")
+	})
+
+	t.Run("PowerOfTwoCapacity", func(t *testing.T) {
+		c := NewCircularBuffer[int](5)
+		assertEqual(t, c.Capacity(), 8, "Capacity rounds up to power of two")
+	})
+
+	t.Run("FullWithoutOverwrite", func(t *testing.T) {
+		c := NewCircularBuffer[int](2) // capacity already power of two
+		c.Push(1)
+		c.Push(2)
+		assertEqual(t, c.Full(), true, "Full once capacity reached")
+		err := c.Push(3)
+		assertError(t, err, "Push into full buffer without overwrite")
+	})
+
+	t.Run("OverwriteEvictsOldest", func(t *testing.T) {
+		c := NewCircularBuffer[int](2)
+		c.SetOverwrite(true)
+		c.Push(1)
+		c.Push(2)
+		err := c.Push(3)
+		assertNoError(t, err, "Push with overwrite does not error")
+		assertEqual(t, c.Size(), 2, "Size stays at capacity after overwrite")
+		v, _ := c.Peek()
+		assertEqual(t, v, 2, "Oldest element evicted")
+	})
+
+	t.Run("Pop_Peek_empty", func(t *testing.T) {
+		c := NewCircularBuffer[int](2)
+		_, err := c.Pop()
+		assertError(t, err, "Pop on empty buffer")
+		_, err = c.Peek()
+		assertError(t, err, "Peek on empty buffer")
+	})
+
+	t.Run("WrapAround", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(1)
+		c.Push(2)
+		c.Push(3)
+		c.Pop()
+		c.Pop()
+		c.Push(4)
+		c.Push(5)
+		c.Push(6) // wraps tail around
+
+		var values []int
+		for !c.IsEmpty() {
+			v, _ := c.Pop()
+			values = append(values, v)
+		}
+		expected := []int{3, 4, 5, 6}
+		for i, v := range expected {
+			assertEqual(t, values[i], v, "WrapAround preserves FIFO order")
+		}
+	})
+
+	t.Run("IsEmpty_Clear", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		assertEqual(t, c.IsEmpty(), true, "New buffer is empty")
+		c.Push(1)
+		c.Clear()
+		assertEqual(t, c.IsEmpty(), true, "Empty after Clear")
+		assertEqual(t, c.Capacity(), 4, "Capacity preserved after Clear")
+	})
+
+	t.Run("Print", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(1)
+		c.Print() // не должно падать
+
+		c2 := NewCircularBuffer[int](4)
+		c2.Print() // пустой
+	})
+
+	t.Run("Serialize_Deserialize", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(1)
+		c.Push(2)
+		c.Push(3)
+
+		var buf bytes.Buffer
+		err := c.Serialize(&buf)
+		assertNoError(t, err, "Serialize binary")
+
+		c2 := NewCircularBuffer[int](1)
+		err = c2.Deserialize(&buf)
+		assertNoError(t, err, "Deserialize binary")
+		assertEqual(t, c2.Size(), 3, "Size after deserialize")
+		v, _ := c2.Peek()
+		assertEqual(t, v, 1, "Data after deserialize")
+	})
+
+	t.Run("SerializeText_DeserializeText", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(1)
+		c.Push(2)
+
+		var buf bytes.Buffer
+		err := c.SerializeText(&buf)
+		assertNoError(t, err, "SerializeText")
+
+		c2 := NewCircularBuffer[int](1)
+		err = c2.DeserializeText(&buf)
+		assertNoError(t, err, "DeserializeText")
+		assertEqual(t, c2.Size(), 2, "Size after DeserializeText")
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(1)
+		c.Push(2)
+		c.Push(3)
+
+		clone := c.Clone()
+		assertEqual(t, clone.Size(), 3, "Clone size")
+		assertEqual(t, clone.Capacity(), 4, "Clone capacity")
+
+		c.Pop()
+		assertEqual(t, clone.Size(), 3, "Clone unaffected by original Pop")
+		v, _ := clone.Peek()
+		assertEqual(t, v, 1, "Clone preserves data")
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(1)
+		c.Push(2)
+		c.Push(3)
+		c.Pop()
+		c.Push(4)
+		c.Push(5) // wraps tail around
+
+		values := c.Values()
+		assertEqual(t, len(values), 4, "Values length matches Size")
+		expected := []int{2, 3, 4, 5}
+		for i, v := range expected {
+			assertEqual(t, values[i], v, "Values preserves logical FIFO order")
+		}
+	})
+
+	t.Run("PeekAt", func(t *testing.T) {
+		c := NewCircularBuffer[int](4)
+		c.Push(10)
+		c.Push(20)
+		c.Push(30)
+
+		v, err := c.PeekAt(0)
+		assertNoError(t, err, "PeekAt(0)")
+		assertEqual(t, v, 10, "PeekAt(0) matches Peek")
+
+		v, err = c.PeekAt(2)
+		assertNoError(t, err, "PeekAt(2)")
+		assertEqual(t, v, 30, "PeekAt(2) is the newest element")
+
+		_, err = c.PeekAt(3)
+		assertError(t, err, "PeekAt out of range")
+		_, err = c.PeekAt(-1)
+		assertError(t, err, "PeekAt negative offset")
+	})
+
+	t.Run("PushEvict", func(t *testing.T) {
+		c := NewCircularBuffer[int](2)
+		_, hadEviction := c.PushEvict(1)
+		assertEqual(t, hadEviction, false, "PushEvict into empty slot: no eviction")
+		_, hadEviction = c.PushEvict(2)
+		assertEqual(t, hadEviction, false, "PushEvict into last empty slot: no eviction")
+
+		evicted, hadEviction := c.PushEvict(3)
+		assertEqual(t, hadEviction, true, "PushEvict into full buffer evicts")
+		assertEqual(t, evicted, 1, "PushEvict evicts the oldest element")
+		assertEqual(t, c.Size(), 2, "PushEvict keeps size at capacity")
+
+		v, _ := c.Peek()
+		assertEqual(t, v, 2, "PushEvict: new oldest element")
+	})
+
+	t.Run("ResizeGrow", func(t *testing.T) {
+		c := NewCircularBuffer[int](2)
+		c.Push(1)
+		c.Push(2)
+
+		c.Resize(8)
+		assertEqual(t, c.Capacity(), 8, "Resize grows to requested power of two")
+		assertEqual(t, c.Size(), 2, "Resize preserves size when growing")
+
+		c.Push(3)
+		assertEqual(t, c.Full(), false, "Buffer has room after growing")
+		values := c.Values()
+		assertEqual(t, len(values), 3, "Values length after growing and pushing")
+	})
+
+	t.Run("ResizeShrinkKeepsNewest", func(t *testing.T) {
+		c := NewCircularBuffer[int](8)
+		for i := 1; i <= 5; i++ {
+			c.Push(i)
+		}
+
+		c.Resize(2)
+		assertEqual(t, c.Capacity(), 2, "Resize shrinks to requested power of two")
+		assertEqual(t, c.Size(), 2, "Resize truncates to new capacity")
+
+		values := c.Values()
+		assertEqual(t, values[0], 4, "Resize keeps the newest elements")
+		assertEqual(t, values[1], 5, "Resize keeps the newest elements")
+	})
+}
+
+func TestBitmap(t *testing.T) {
+	t.Run("SetClearExists", func(t *testing.T) {
+		bm := NewBitmap(128)
+		bm.Set(3)
+		bm.Set(65)
+		assertEqual(t, bm.Exists(3), true, "Exists after Set")
+		assertEqual(t, bm.Exists(65), true, "Exists after Set in second word")
+		assertEqual(t, bm.Exists(4), false, "Exists false for unset bit")
+
+		bm.Clear(3)
+		assertEqual(t, bm.Exists(3), false, "Exists false after Clear")
+	})
+
+	t.Run("GrowsBeyondInitialCapacity", func(t *testing.T) {
+		bm := NewBitmap(8)
+		bm.Set(500)
+		assertEqual(t, bm.Exists(500), true, "Set grows backing storage")
+	})
+
+	t.Run("Union", func(t *testing.T) {
+		a := NewBitmap(64)
+		a.Set(1)
+		a.Set(2)
+		b := NewBitmap(64)
+		b.Set(2)
+		b.Set(3)
+
+		u := a.Union(b)
+		assertEqual(t, u.Exists(1), true, "Union contains bit only in a")
+		assertEqual(t, u.Exists(2), true, "Union contains bit in both")
+		assertEqual(t, u.Exists(3), true, "Union contains bit only in b")
+		assertEqual(t, u.PopCount(), 3, "Union pop count")
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		a := NewBitmap(64)
+		a.Set(1)
+		a.Set(2)
+		b := NewBitmap(64)
+		b.Set(2)
+		b.Set(3)
+
+		i := a.Intersect(b)
+		assertEqual(t, i.Exists(2), true, "Intersect contains common bit")
+		assertEqual(t, i.Exists(1), false, "Intersect excludes bit unique to a")
+		assertEqual(t, i.PopCount(), 1, "Intersect pop count")
+	})
+
+	t.Run("Difference", func(t *testing.T) {
+		a := NewBitmap(64)
+		a.Set(1)
+		a.Set(2)
+		b := NewBitmap(64)
+		b.Set(2)
+
+		d := a.Difference(b)
+		assertEqual(t, d.Exists(1), true, "Difference keeps bit unique to a")
+		assertEqual(t, d.Exists(2), false, "Difference removes common bit")
+	})
+
+	t.Run("Inverse", func(t *testing.T) {
+		bm := NewBitmap(0)
+		bm.Set(0)
+		inv := bm.Inverse()
+		assertEqual(t, inv.Exists(0), false, "Inverse clears previously set bit")
+		assertEqual(t, inv.Exists(1), true, "Inverse sets previously unset bit within range")
+	})
+
+	t.Run("PopCount_IsEmpty_ClearAll", func(t *testing.T) {
+		bm := NewBitmap(64)
+		assertEqual(t, bm.IsEmpty(), true, "New bitmap is empty")
+		bm.Set(1)
+		bm.Set(10)
+		bm.Set(20)
+		assertEqual(t, bm.PopCount(), 3, "PopCount after three sets")
+		assertEqual(t, bm.IsEmpty(), false, "Not empty after sets")
+		bm.ClearAll()
+		assertEqual(t, bm.IsEmpty(), true, "Empty after ClearAll")
+	})
+
+	t.Run("SetBitsIterator", func(t *testing.T) {
+		bm := NewBitmap(200)
+		bm.Set(5)
+		bm.Set(70)
+		bm.Set(199)
+
+		var indices []uint
+		for i := range bm.SetBits() {
+			indices = append(indices, i)
+		}
+		assertEqual(t, len(indices), 3, "SetBits yields all set indices")
+		assertEqual(t, indices[0], uint(5), "SetBits in ascending order")
+		assertEqual(t, indices[1], uint(70), "SetBits in ascending order")
+		assertEqual(t, indices[2], uint(199), "SetBits in ascending order")
+	})
+
+	t.Run("Print", func(t *testing.T) {
+		bm := NewBitmap(64)
+		bm.Set(1)
+		bm.Print() // не должно падать
+
+		bm2 := NewBitmap(64)
+		bm2.Print() // пустой
+	})
+
+	t.Run("Serialize_Deserialize", func(t *testing.T) {
+		bm := NewBitmap(64)
+		bm.Set(1)
+		bm.Set(63)
+
+		var buf bytes.Buffer
+		err := bm.Serialize(&buf)
+		assertNoError(t, err, "Serialize gob")
+
+		bm2 := NewBitmap(0)
+		err = bm2.Deserialize(&buf)
+		assertNoError(t, err, "Deserialize gob")
+		assertEqual(t, bm2.Exists(1), true, "Data after deserialize")
+		assertEqual(t, bm2.Exists(63), true, "Data after deserialize")
+	})
+
+	t.Run("SerializeCompact_DeserializeCompact", func(t *testing.T) {
+		bm := NewBitmap(128)
+		bm.Set(1)
+		bm.Set(100)
+
+		var buf bytes.Buffer
+		err := bm.SerializeCompact(&buf)
+		assertNoError(t, err, "SerializeCompact")
+
+		bm2 := NewBitmap(0)
+		err = bm2.DeserializeCompact(&buf)
+		assertNoError(t, err, "DeserializeCompact")
+		assertEqual(t, bm2.Exists(1), true, "Data after DeserializeCompact")
+		assertEqual(t, bm2.Exists(100), true, "Data after DeserializeCompact")
+	})
+}
+
+// TestARTree_Coverage тестирует Adaptive Radix Tree
+func TestARTree_Coverage(t *testing.T) {
+	t.Run("Insert_Get_Update", func(t *testing.T) {
+		tree := NewARTree[int]()
+		tree.Insert([]byte("apple"), 1)
+		tree.Insert([]byte("apply"), 2)
+		tree.Insert([]byte("banana"), 3)
+		assertEqual(t, tree.Size(), 3, "Size after inserts")
+
+		v, ok := tree.Get([]byte("apple"))
+		assertEqual(t, ok, true, "Get apple found")
+		assertEqual(t, v, 1, "Get apple value")
+
+		v, ok = tree.Get([]byte("apply"))
+		assertEqual(t, ok, true, "Get apply found")
+		assertEqual(t, v, 2, "Get apply value")
+
+		_, ok = tree.Get([]byte("missing"))
+		assertEqual(t, ok, false, "Get missing not found")
+
+		tree.Insert([]byte("apple"), 100)
+		assertEqual(t, tree.Size(), 3, "Size unchanged after update")
+		v, _ = tree.Get([]byte("apple"))
+		assertEqual(t, v, 100, "Get apple after update")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		tree := NewARTree[int]()
+		keys := []string{"apple", "apply", "banana", "band", "bandana"}
+		for i, k := range keys {
+			tree.Insert([]byte(k), i)
+		}
+
+		ok := tree.Delete([]byte("apply"))
+		assertEqual(t, ok, true, "Delete existing key")
+		_, found := tree.Get([]byte("apply"))
+		assertEqual(t, found, false, "Deleted key not found")
+		assertEqual(t, tree.Size(), len(keys)-1, "Size after delete")
+
+		ok = tree.Delete([]byte("missing"))
+		assertEqual(t, ok, false, "Delete missing key")
+
+		for _, k := range keys {
+			if k == "apply" {
+				continue
+			}
+			_, found := tree.Get([]byte(k))
+			assertEqual(t, found, true, "Remaining key still present: "+k)
+		}
+	})
+
+	t.Run("MinimumMaximum", func(t *testing.T) {
+		tree := NewARTree[int]()
+		_, _, ok := tree.Minimum()
+		assertEqual(t, ok, false, "Minimum on empty tree")
+
+		words := []string{"delta", "alpha", "charlie", "bravo"}
+		for i, w := range words {
+			tree.Insert([]byte(w), i)
+		}
+
+		minKey, _, ok := tree.Minimum()
+		assertEqual(t, ok, true, "Minimum found")
+		assertEqual(t, string(minKey), "alpha", "Minimum key")
+
+		maxKey, _, ok := tree.Maximum()
+		assertEqual(t, ok, true, "Maximum found")
+		assertEqual(t, string(maxKey), "delta", "Maximum key")
+	})
+
+	t.Run("PrefixScan", func(t *testing.T) {
+		tree := NewARTree[int]()
+		words := []string{"car", "card", "care", "cat", "dog"}
+		for i, w := range words {
+			tree.Insert([]byte(w), i)
+		}
+
+		var matched []string
+		tree.PrefixScan([]byte("car"), func(k []byte, v int) bool {
+			matched = append(matched, string(k))
+			return true
+		})
+		assertEqual(t, len(matched), 3, "PrefixScan car count")
+		for _, w := range []string{"car", "card", "care"} {
+			found := false
+			for _, m := range matched {
+				if m == w {
+					found = true
+				}
+			}
+			assertEqual(t, found, true, "PrefixScan contains "+w)
+		}
+
+		var all []string
+		tree.PrefixScan(nil, func(k []byte, v int) bool {
+			all = append(all, string(k))
+			return true
+		})
+		assertEqual(t, len(all), len(words), "PrefixScan empty prefix visits all")
+
+		var stoppedEarly []string
+		tree.PrefixScan(nil, func(k []byte, v int) bool {
+			stoppedEarly = append(stoppedEarly, string(k))
+			return false
+		})
+		assertEqual(t, len(stoppedEarly), 1, "PrefixScan stops when fn returns false")
+	})
+
+	t.Run("NodeKindDistribution", func(t *testing.T) {
+		tree := NewARTree[int]()
+		for i := 0; i < 300; i++ {
+			key := []byte{byte(i % 256), byte(i / 256)}
+			tree.Insert(key, i)
+		}
+		counts := tree.CountNodeKinds()
+		assertEqual(t, counts.Leaves, 300, "Leaf count matches inserted keys")
+		total := counts.Node4 + counts.Node16 + counts.Node48 + counts.Node256
+		assertEqual(t, total > 0, true, "At least one internal node exists")
+	})
+
+	t.Run("Serialize_Deserialize", func(t *testing.T) {
+		tree := NewARTree[int]()
+		words := []string{"one", "two", "three", "four", "five"}
+		for i, w := range words {
+			tree.Insert([]byte(w), i)
+		}
+
+		var buf bytes.Buffer
+		err := tree.Serialize(&buf)
+		assertNoError(t, err, "Serialize")
+
+		restored := NewARTree[int]()
+		err = restored.Deserialize(&buf)
+		assertNoError(t, err, "Deserialize")
+		assertEqual(t, restored.Size(), tree.Size(), "Size after deserialize")
+
+		for i, w := range words {
+			v, ok := restored.Get([]byte(w))
+			assertEqual(t, ok, true, "Restored key found: "+w)
+			assertEqual(t, v, i, "Restored value: "+w)
+		}
+	})
+}
+
+// TestBufferPool тестирует тиированный пул буферов для сериализации
+func TestBufferPool(t *testing.T) {
+	t.Run("GetReturnsRequestedCapacity", func(t *testing.T) {
+		buf := bufferPoolGet(100)
+		assertEqual(t, len(buf), 0, "Buffer starts empty")
+		assertEqual(t, cap(buf) >= 100, true, "Buffer capacity covers requested size")
+		bufferPoolPut(buf)
+	})
+
+	t.Run("OversizedRequestBypassesPool", func(t *testing.T) {
+		statsBefore := GetBufferPoolStats()
+		buf := bufferPoolGet(8 * 1024 * 1024)
+		assertEqual(t, cap(buf) >= 8*1024*1024, true, "Oversized buffer still has requested capacity")
+		statsAfter := GetBufferPoolStats()
+		assertEqual(t, statsAfter.Misses > statsBefore.Misses, true, "Oversized request counts as a miss")
+	})
+
+	t.Run("PutReuseIncrementsHits", func(t *testing.T) {
+		buf := bufferPoolGet(10)
+		bufferPoolPut(buf)
+
+		statsBefore := GetBufferPoolStats()
+		reused := bufferPoolGet(10)
+		statsAfter := GetBufferPoolStats()
+		assertEqual(t, statsAfter.Hits > statsBefore.Hits, true, "Reused buffer counts as a hit")
+		assertEqual(t, len(reused), 0, "Reused buffer is reset to zero length")
+		bufferPoolPut(reused)
+	})
+
+	t.Run("SerializationStillRoundTrips", func(t *testing.T) {
+		arr := NewArray[int](0)
+		for i := 0; i < 50; i++ {
+			arr.Add(i)
+		}
+		var buf bytes.Buffer
+		err := arr.Serialize(&buf)
+		assertNoError(t, err, "Array.Serialize with pooled buffer")
+
+		restored := NewArray[int](0)
+		err = restored.Deserialize(&buf)
+		assertNoError(t, err, "Array.Deserialize with pooled buffer")
+		assertEqual(t, restored.Size(), arr.Size(), "Size after round trip")
+	})
+}
+
+// assertContainer прогоняет общие проверки Container[T] — не зависящие от
+// конкретной структуры данных, используется TestContainer_Coverage для
+// каждого контейнера пакета.
+func assertContainer[T any](t *testing.T, name string, c Container[T], expectedSize int) {
+	t.Helper()
+	assertEqual(t, c.Size(), expectedSize, name+".Size")
+	assertEqual(t, c.Empty(), expectedSize == 0, name+".Empty")
+	assertEqual(t, len(c.Values()), expectedSize, name+".Values length")
+	if c.String() == "" {
+		t.Errorf("%s.String returned empty string", name)
+	}
+}
+
+// TestContainer_Coverage тестирует общий интерфейс Container[T] и курсоры
+// ForwardEnumerator/BidirectionalEnumerator на всех контейнерах пакета.
+func TestContainer_Coverage(t *testing.T) {
+	t.Run("Array", func(t *testing.T) {
+		arr := NewArray[int](0)
+		arr.Add(1)
+		arr.Add(2)
+		arr.Add(3)
+		assertContainer[int](t, "Array", arr, 3)
+
+		it := arr.Iterator()
+		assertEqual(t, it.Valid(), true, "Array.Iterator starts valid")
+		it.Next()
+		it.Next()
+		it.Next()
+		assertEqual(t, it.Valid(), false, "Array.Iterator exhausted")
+		it.Reset()
+		assertEqual(t, it.Valid(), true, "Array.Iterator valid after Reset")
+		assertEqual(t, *it.Value(), 1, "Array.Iterator value after Reset")
+	})
+
+	t.Run("ForwardList", func(t *testing.T) {
+		list := NewForwardList[int]()
+		list.PushFront(1)
+		list.PushFront(2)
+		assertContainer[int](t, "ForwardList", list, 2)
+
+		it := list.Iterator()
+		it.Next()
+		it.Reset()
+		assertEqual(t, it.Valid(), true, "ForwardList.Iterator valid after Reset")
+	})
+
+	t.Run("DoubleList", func(t *testing.T) {
+		list := NewDoubleList[int]()
+		list.PushBack(1)
+		list.PushBack(2)
+		assertContainer[int](t, "DoubleList", list, 2)
+
+		it := list.Iterator()
+		it.Next()
+		it.Next()
+		it.Reset()
+		assertEqual(t, it.Valid(), true, "DoubleList.Iterator valid after Reset")
+	})
+
+	t.Run("Stack", func(t *testing.T) {
+		s := NewStack[int]()
+		s.Push(1)
+		s.Push(2)
+		assertContainer[int](t, "Stack", s, 2)
+
+		it := s.Iterator()
+		it.Next()
+		it.Reset()
+		assertEqual(t, it.Valid(), true, "Stack.Iterator valid after Reset")
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		q := NewQueue[int]()
+		q.Enqueue(1)
+		q.Enqueue(2)
+		assertContainer[int](t, "Queue", q, 2)
+
+		it := q.Iterator()
+		it.Next()
+		it.Reset()
+		assertEqual(t, it.Valid(), true, "Queue.Iterator valid after Reset")
+	})
+
+	t.Run("HashTable", func(t *testing.T) {
+		ht := NewHashTable[string, int]()
+		ht.Insert("a", 1)
+		ht.Insert("b", 2)
+		assertContainer[HashEntry[string, int]](t, "HashTable", ht, 2)
+
+		it := ht.Iterator()
+		it.Next()
+		it.Reset()
+		assertEqual(t, it.Valid(), true, "HashTable.Iterator valid after Reset")
+	})
+
+	t.Run("FullBinaryTree", func(t *testing.T) {
+		tree := NewFullBinaryTree[int]()
+		tree.Insert(1)
+		tree.Insert(2)
+		assertContainer[int](t, "FullBinaryTree", tree, 3)
+
+		it := tree.Iterator()
+		it.Next()
+		it.Reset()
+		assertEqual(t, it.Valid(), true, "FullBinaryTree.Iterator valid after Reset")
+	})
+
+	t.Run("ReduceSum", func(t *testing.T) {
+		arr := NewArray[int](0)
+		arr.Add(1)
+		arr.Add(2)
+		arr.Add(3)
+		sum := Reduce[int, int](arr.Begin(), 0, func(acc, v int) int { return acc + v })
+		assertEqual(t, sum, 6, "Reduce sums array elements")
+	})
+}
+
+// TestConcurrentContainers_Coverage покрывает ConcurrentQueue, ConcurrentStack и BlockingQueue
+func TestConcurrentContainers_Coverage(t *testing.T) {
+	t.Run("ConcurrentQueue", func(t *testing.T) {
+		q := NewConcurrentQueue[int]()
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(v int) {
+				defer wg.Done()
+				q.Enqueue(v)
+			}(i)
+		}
+		wg.Wait()
+		assertEqual(t, q.Size(), 100, "ConcurrentQueue size after concurrent Enqueue")
+
+		for i := 0; i < 100; i++ {
+			assertNoError(t, q.Dequeue(), "ConcurrentQueue Dequeue")
+		}
+		assertEqual(t, q.IsEmpty(), true, "ConcurrentQueue empty after draining")
+
+		q.Enqueue(1)
+		var buf bytes.Buffer
+		assertNoError(t, q.Serialize(&buf), "ConcurrentQueue Serialize")
+		q2 := NewConcurrentQueue[int]()
+		assertNoError(t, q2.Deserialize(&buf), "ConcurrentQueue Deserialize")
+		front, _ := q2.Front()
+		assertEqual(t, front, 1, "ConcurrentQueue Deserialize data")
+
+		q2.Clear()
+		assertEqual(t, q2.IsEmpty(), true, "ConcurrentQueue empty after Clear")
+	})
+
+	t.Run("ConcurrentStack", func(t *testing.T) {
+		s := NewConcurrentStack[int]()
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(v int) {
+				defer wg.Done()
+				s.Push(v)
+			}(i)
+		}
+		wg.Wait()
+		assertEqual(t, s.Size(), 100, "ConcurrentStack size after concurrent Push")
+
+		for i := 0; i < 100; i++ {
+			assertNoError(t, s.Pop(), "ConcurrentStack Pop")
+		}
+		assertEqual(t, s.IsEmpty(), true, "ConcurrentStack empty after draining")
+
+		s.Push(42)
+		var buf bytes.Buffer
+		assertNoError(t, s.Serialize(&buf), "ConcurrentStack Serialize")
+		s2 := NewConcurrentStack[int]()
+		assertNoError(t, s2.Deserialize(&buf), "ConcurrentStack Deserialize")
+		top, _ := s2.Top()
+		assertEqual(t, top, 42, "ConcurrentStack Deserialize data")
+	})
+
+	t.Run("BlockingQueue_ProducerConsumer", func(t *testing.T) {
+		bq := NewBoundedBlockingQueue[int](4)
+		ctx := context.Background()
+		const n = 50
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if err := bq.Put(ctx, i); err != nil {
+					t.Errorf("Put: %v", err)
+				}
+			}
+		}()
+
+		sum := 0
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				v, err := bq.Take(ctx)
+				if err != nil {
+					t.Errorf("Take: %v", err)
+					continue
+				}
+				sum += v
+			}
+		}()
+		wg.Wait()
+
+		assertEqual(t, sum, n*(n-1)/2, "BlockingQueue producer/consumer sum")
+		assertEqual(t, bq.IsEmpty(), true, "BlockingQueue empty after draining")
+	})
+
+	t.Run("BlockingQueue_OfferPoll", func(t *testing.T) {
+		bq := NewBoundedBlockingQueue[int](2)
+		assertEqual(t, bq.Offer(1), true, "Offer into space")
+		assertEqual(t, bq.Offer(2), true, "Offer into space")
+		assertEqual(t, bq.Offer(3), false, "Offer into full bounded queue")
+
+		v, ok := bq.Poll()
+		assertEqual(t, ok, true, "Poll non-empty")
+		assertEqual(t, v, 1, "Poll returns front value")
+
+		bq.Clear()
+		_, ok = bq.Poll()
+		assertEqual(t, ok, false, "Poll empty")
+	})
+
+	t.Run("BlockingQueue_PutCancelled", func(t *testing.T) {
+		bq := NewBoundedBlockingQueue[int](1)
+		bq.Put(context.Background(), 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- bq.Put(ctx, 2)
+		}()
+		cancel()
+		err := <-done
+		assertError(t, err, "Put on full queue cancelled via ctx")
+	})
+
+	t.Run("BlockingQueue_TakeCancelled", func(t *testing.T) {
+		bq := NewBoundedBlockingQueue[int](1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			_, err := bq.Take(ctx)
+			done <- err
+		}()
+		cancel()
+		err := <-done
+		assertError(t, err, "Take on empty queue cancelled via ctx")
+	})
+}
+
 // TestErrorCoverage тестирует обработку ошибок
 func TestErrorCoverage(t *testing.T) {
 	// Array ошибки